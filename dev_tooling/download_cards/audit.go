@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// AuditEntry records a single HTTP interaction made during a run
+type AuditEntry struct {
+	Time       time.Time `json:"time"`
+	Kind       string    `json:"kind"` // "api", "download" or "local"
+	File       string    `json:"file"`
+	URL        string    `json:"url"`
+	Status     int       `json:"status"`
+	Bytes      int64     `json:"bytes"`
+	DurationMs int64     `json:"duration_ms"`
+	Retries    int       `json:"retries"`
+	Cached     bool      `json:"cached,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// AuditLog collects every request made during a run so asset provenance
+// can be traced and Wikimedia usage shown to be policy-compliant
+type AuditLog struct {
+	mu        sync.Mutex
+	UserAgent string       `json:"user_agent"`
+	Started   time.Time    `json:"started"`
+	Finished  time.Time    `json:"finished"`
+	Entries   []AuditEntry `json:"entries"`
+}
+
+// NewAuditLog starts a new audit log for this run
+func NewAuditLog(userAgent string) *AuditLog {
+	return &AuditLog{
+		UserAgent: userAgent,
+		Started:   time.Now().UTC(),
+		Entries:   []AuditEntry{},
+	}
+}
+
+// Record appends an entry to the log
+func (a *AuditLog) Record(entry AuditEntry) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.Entries = append(a.Entries, entry)
+}
+
+// WriteFile writes the log as JSON into dir, named after the run start time
+func (a *AuditLog) WriteFile(dir string) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", err
+	}
+	a.Finished = time.Now().UTC()
+
+	name := fmt.Sprintf("audit-%s.json", a.Started.Format("20060102T150405Z"))
+	logPath := filepath.Join(dir, name)
+
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(logPath, data, 0o644); err != nil {
+		return "", err
+	}
+	return logPath, nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Cache is an on-disk store of HTTP response bodies keyed by URL hash.
+// Entries older than TTL are treated as missing. A nil Cache is disabled.
+type Cache struct {
+	Dir string
+	TTL time.Duration
+}
+
+// NewCache returns a cache rooted at dir, or nil if dir is empty
+func NewCache(dir string, ttl time.Duration) (*Cache, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	return &Cache{Dir: dir, TTL: ttl}, nil
+}
+
+// Helper function to map a URL to its cache file
+func (c *Cache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:]))
+}
+
+// Get returns the cached body for url if present and not expired
+func (c *Cache) Get(url string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	p := c.path(url)
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, false
+	}
+	if c.TTL > 0 && time.Since(info.ModTime()) > c.TTL {
+		return nil, false
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Put stores body for url, replacing any previous entry
+func (c *Cache) Put(url string, body []byte) error {
+	if c == nil {
+		return nil
+	}
+	// Write to a temp file and rename so an interrupted run never leaves a truncated entry
+	tmp, err := os.CreateTemp(c.Dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(url))
+}
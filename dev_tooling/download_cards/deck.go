@@ -0,0 +1,121 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Deck describes a downloadable card art pack
+type Deck struct {
+	Name        string
+	Description string
+	Author      string
+	License     string
+	SourceURL   string // Commons category or project page the art comes from
+
+	// Filename returns the source filename for a card
+	Filename func(rank, suit string) string
+}
+
+// Available deck presets, keyed by the -deck flag value.
+// Add new packs here; every file must be freely licensed, and each pack needs
+// its license recorded so it ends up in the manifest and ATTRIBUTION.txt.
+var decks = map[string]Deck{
+	"english": {
+		Name:        "english",
+		Description: "SVG English pattern playing cards",
+		Author:      "Byron Knoll",
+		License:     "Public Domain",
+		SourceURL:   "https://commons.wikimedia.org/wiki/Category:SVG_English_pattern_playing_cards",
+		Filename: func(rank, suit string) string {
+			// Byron Knoll uses lowercase for all ranks in the filename
+			return fmt.Sprintf("English_pattern_%s_of_%s.svg", rank, suit)
+		},
+	},
+}
+
+// Helper function to list preset names for usage and error messages
+func deckNames() []string {
+	names := make([]string, 0, len(decks))
+	for name := range decks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ManifestCard records provenance for one downloaded card
+type ManifestCard struct {
+	Rank      string `json:"rank"`
+	Suit      string `json:"suit"`
+	File      string `json:"file"`
+	SourceURL string `json:"source_url"`
+	SHA256    string `json:"sha256"`
+	Bytes     int64  `json:"bytes"`
+}
+
+// Manifest describes the contents of a deck's asset folder
+type Manifest struct {
+	Deck        string         `json:"deck"`
+	Description string         `json:"description"`
+	Author      string         `json:"author"`
+	License     string         `json:"license"`
+	SourceURL   string         `json:"source_url"`
+	Generated   time.Time      `json:"generated"`
+	Cards       []ManifestCard `json:"cards"`
+}
+
+// NewManifest starts an empty manifest for deck
+func NewManifest(deck Deck) *Manifest {
+	return &Manifest{
+		Deck:        deck.Name,
+		Description: deck.Description,
+		Author:      deck.Author,
+		License:     deck.License,
+		SourceURL:   deck.SourceURL,
+		Cards:       []ManifestCard{},
+	}
+}
+
+// Add hashes the card at localPath and appends it to the manifest
+func (m *Manifest) Add(rank, suit, localPath, sourceURL string) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return err
+	}
+
+	m.Cards = append(m.Cards, ManifestCard{
+		Rank:      rank,
+		Suit:      suit,
+		File:      filepath.Base(localPath),
+		SourceURL: sourceURL,
+		SHA256:    hex.EncodeToString(h.Sum(nil)),
+		Bytes:     n,
+	})
+	return nil
+}
+
+// WriteFile writes the manifest as manifest.json in dir
+func (m *Manifest) WriteFile(dir string) (string, error) {
+	m.Generated = time.Now().UTC()
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	manifestPath := filepath.Join(dir, "manifest.json")
+	return manifestPath, os.WriteFile(manifestPath, data, 0o644)
+}
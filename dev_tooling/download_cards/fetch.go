@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Minimum gap between network requests, to be respectful to Wikimedia
+const requestInterval = 500 * time.Millisecond
+
+// Retry policy for lag, read-only and rate-limit responses
+const (
+	maxRetries        = 5
+	defaultRetryAfter = 5 * time.Second
+	maxRetryAfter     = 2 * time.Minute
+)
+
+var (
+	// Time of the last network request, used for rate limiting
+	lastRequest time.Time
+
+	// Requests are held until this time after the server asks us to back off.
+	// This pauses every card, not just the one that got the response.
+	pausedUntil time.Time
+)
+
+// Helper function to wait until the next network request is allowed
+func throttle() {
+	next := lastRequest.Add(requestInterval)
+	if pausedUntil.After(next) {
+		next = pausedUntil
+	}
+	if wait := time.Until(next); wait > 0 {
+		time.Sleep(wait)
+	}
+	lastRequest = time.Now()
+}
+
+// Helper function to hold all network requests for d
+func pause(d time.Duration) {
+	if until := time.Now().Add(d); until.After(pausedUntil) {
+		pausedUntil = until
+	}
+}
+
+// Helper function to read the Retry-After header (seconds or HTTP date)
+func retryAfter(resp *http.Response) time.Duration {
+	d := defaultRetryAfter
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			d = time.Duration(secs) * time.Second
+		} else if t, err := http.ParseTime(v); err == nil {
+			d = time.Until(t)
+		}
+	}
+	if d < 0 {
+		d = 0
+	}
+	if d > maxRetryAfter {
+		d = maxRetryAfter
+	}
+	return d
+}
+
+// Helper function to read the MediaWiki error object from a response body, if any.
+// Non-JSON bodies such as downloaded SVGs never have one.
+func apiError(body []byte) *WikimediaError {
+	var result struct {
+		Error *WikimediaError `json:"error"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil
+	}
+	return result.Error
+}
+
+// Helper function to spot API errors that mean "try again later" rather than failure
+func isLagError(apiErr *WikimediaError) bool {
+	return apiErr != nil && (apiErr.Code == "maxlag" || apiErr.Code == "readonly")
+}
+
+// Helper function to GET a URL, serving from cache when possible.
+// Every call is recorded in the audit log.
+func fetch(url, kind, filename string, timeout time.Duration, audit *AuditLog, cache *Cache) (body []byte, err error) {
+	entry := AuditEntry{Time: time.Now().UTC(), Kind: kind, File: filename, URL: url}
+	start := time.Now()
+	defer func() {
+		entry.DurationMs = time.Since(start).Milliseconds()
+		entry.Bytes = int64(len(body))
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		audit.Record(entry)
+	}()
+
+	// Serve from cache without touching the network
+	if data, ok := cache.Get(url); ok {
+		entry.Cached = true
+		entry.Status = http.StatusOK
+		return data, nil
+	}
+
+	// Create HTTP client with proper user-agent
+	client := &http.Client{
+		Timeout: timeout,
+	}
+
+	for attempt := 0; ; attempt++ {
+		entry.Retries = attempt
+
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// Set user-agent header to comply with Wikimedia policy
+		req.Header.Set("User-Agent", userAgent)
+
+		throttle()
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		entry.Status = resp.StatusCode
+
+		body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		var apiErr *WikimediaError
+		if resp.StatusCode == http.StatusOK && kind == "api" {
+			apiErr = apiError(body)
+		}
+
+		// Server asked us to back off: pause everything and try again
+		reason := ""
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode == http.StatusServiceUnavailable:
+			reason = http.StatusText(resp.StatusCode)
+		case isLagError(apiErr):
+			reason = apiErr.Code
+		}
+		if reason != "" {
+			if attempt >= maxRetries {
+				return nil, fmt.Errorf("gave up after %d retries: %s", attempt, reason)
+			}
+			wait := retryAfter(resp)
+			fmt.Printf("  Server busy (%s), pausing all requests for %s\n", reason, wait)
+			pause(wait)
+			continue
+		}
+
+		// Check status code
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+
+		// Don't cache API errors, so a one-off failure isn't replayed for the whole TTL
+		if apiErr != nil {
+			return body, nil
+		}
+		if err := cache.Put(url, body); err != nil {
+			fmt.Printf("  Warning: could not cache response: %v\n", err)
+		}
+		return body, nil
+	}
+}
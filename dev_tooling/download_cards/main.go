@@ -2,162 +2,315 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"os"
 	"path"
+	"strconv"
+	"strings"
 	"time"
 )
 
-// Wikimedia API response structures
+// User-agent sent with every request to comply with Wikimedia policy
+const userAgent = "FreeCell Card Downloader/1.0 (https://github.com/joshuamkite/freecell; josh@joshuamkite.com)"
+
+// Wikimedia API response structures (formatversion=2)
 type WikimediaResponse struct {
-	Query struct {
-		Pages map[string]struct {
-			ImageInfo []struct {
+	Error    *WikimediaError   `json:"error"`
+	Continue map[string]string `json:"continue"`
+	Query    struct {
+		Normalized []TitleMapping `json:"normalized"`
+		Redirects  []TitleMapping `json:"redirects"`
+		Pages      []struct {
+			Title         string `json:"title"`
+			Missing       bool   `json:"missing"`
+			Invalid       bool   `json:"invalid"`
+			InvalidReason string `json:"invalidreason"`
+			ImageInfo     []struct {
 				URL string `json:"url"`
 			} `json:"imageinfo"`
 		} `json:"pages"`
 	} `json:"query"`
 }
 
+// WikimediaError is the error object returned by the API on failure
+type WikimediaError struct {
+	Code string `json:"code"`
+	Info string `json:"info"`
+}
+
+// TitleMapping describes a title the API normalized or followed as a redirect
+type TitleMapping struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Seconds of database replication lag above which the API should refuse our queries
+var maxlag = 5
+
+// Upper bound on continuation requests for a single file, in case the API never settles
+const maxContinuations = 10
+
 // Helper function to get the actual image URL from Wikimedia API
-func getImageURL(filename string) (string, error) {
+func getImageURL(filename string, audit *AuditLog, cache *Cache) (string, error) {
 	// Construct API URL
 	baseURL := "https://commons.wikimedia.org/w/api.php"
+	requested := "File:" + filename
 	params := url.Values{}
 	params.Add("action", "query")
-	params.Add("titles", "File:"+filename)
+	params.Add("titles", requested)
 	params.Add("prop", "imageinfo")
 	params.Add("iiprop", "url")
+	params.Add("redirects", "1")
 	params.Add("format", "json")
+	params.Add("formatversion", "2")
+	if maxlag > 0 {
+		params.Add("maxlag", strconv.Itoa(maxlag))
+	}
 
-	apiURL := baseURL + "?" + params.Encode()
+	// Follow the API's title normalization and redirects to the page we actually get back
+	title := requested
 
-	// Create HTTP client with proper user-agent
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-	}
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return "", err
-	}
+	for i := 0; i <= maxContinuations; i++ {
+		apiURL := baseURL + "?" + params.Encode()
 
-	// Set user-agent header to comply with Wikimedia policy
-	req.Header.Set("User-Agent", "FreeCell Card Downloader/1.0 (https://github.com/joshuamkite/freecell; josh@joshuamkite.com)")
+		body, err := fetch(apiURL, "api", filename, 30*time.Second, audit, cache)
+		if err != nil {
+			return "", err
+		}
 
-	// Make the request
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
+		// Parse JSON response
+		var result WikimediaResponse
+		if err := json.Unmarshal(body, &result); err != nil {
+			return "", err
+		}
+		if result.Error != nil {
+			return "", fmt.Errorf("API error %s: %s", result.Error.Code, result.Error.Info)
+		}
 
-	// Parse JSON response
-	var result WikimediaResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", err
-	}
+		title = resolveTitle(title, result.Query.Normalized)
+		title = resolveTitle(title, result.Query.Redirects)
 
-	// Extract image URL from response
-	for _, page := range result.Query.Pages {
-		if len(page.ImageInfo) > 0 {
-			return page.ImageInfo[0].URL, nil
+		// Extract image URL from response
+		for _, page := range result.Query.Pages {
+			// A single-title query returns one page; only match on title if there are several
+			if len(result.Query.Pages) > 1 && page.Title != title {
+				continue
+			}
+			if page.Invalid {
+				return "", fmt.Errorf("invalid title %q: %s", requested, page.InvalidReason)
+			}
+			if page.Missing {
+				if title != requested {
+					return "", fmt.Errorf("%q resolved to %q, which does not exist on Wikimedia Commons", requested, title)
+				}
+				return "", fmt.Errorf("%q does not exist on Wikimedia Commons - check the filename", requested)
+			}
+			if len(page.ImageInfo) > 0 && page.ImageInfo[0].URL != "" {
+				return page.ImageInfo[0].URL, nil
+			}
+		}
+
+		// Image info may arrive in a later batch
+		if len(result.Continue) == 0 {
+			break
+		}
+		for key, value := range result.Continue {
+			params.Set(key, value)
 		}
 	}
 
-	return "", fmt.Errorf("no image URL found")
+	return "", fmt.Errorf("no image URL returned for %q (resolved to %q)", requested, title)
 }
 
-// Helper function to download a file
-func downloadFile(url, filepath string) error {
-	// Create HTTP client with proper user-agent
-	client := &http.Client{
-		Timeout: 60 * time.Second,
+// Helper function to follow a title through a list of normalizations or redirects
+func resolveTitle(title string, mappings []TitleMapping) string {
+	for _, m := range mappings {
+		if m.From == title {
+			return m.To
+		}
 	}
-	req, err := http.NewRequest("GET", url, nil)
+	return title
+}
+
+// Helper function to download a file
+func downloadFile(url, filepath, filename string, audit *AuditLog, cache *Cache) error {
+	body, err := fetch(url, "download", filename, 60*time.Second, audit, cache)
 	if err != nil {
 		return err
 	}
 
-	// Set user-agent header to comply with Wikimedia policy
-	req.Header.Set("User-Agent", "FreeCell Card Downloader/1.0 (https://github.com/joshuamkite/freecell; josh@joshuamkite.com)")
+	// Write the body to file
+	return os.WriteFile(filepath, body, 0o644)
+}
 
-	// Get the data
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
+func main() {
+	os.Exit(run())
+}
+
+// run downloads the cards and returns the process exit code
+func run() int {
+	flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
+	auditDir := flag.String("audit-dir", "audit", "directory to write the JSON audit log for this run")
+	summaryPath := flag.String("summary", "summary.json", "path to write the machine-readable run summary")
+	cacheDir := flag.String("cache-dir", ".cache", "directory for cached API responses and downloads (empty disables caching)")
+	cacheTTL := flag.Duration("cache-ttl", 7*24*time.Hour, "how long cached responses stay fresh (0 never expires)")
+	flag.IntVar(&maxlag, "maxlag", maxlag, "maxlag value sent with API queries, in seconds (0 disables)")
+	outDir := flag.String("out", "../../src/assets/cards", "base directory for card assets; each deck gets its own folder")
+	deckName := flag.String("deck", "english", "deck preset to download ("+strings.Join(deckNames(), ", ")+")")
+	normalize := flag.Bool("normalize", true, "normalize every card SVG to the same dimensions and aspect ratio")
+	// 5:7, the ratio the game draws cards at, and the size of the generated card backs
+	cardWidth := flag.Float64("card-width", 250, "width cards are normalized to")
+	cardHeight := flag.Float64("card-height", 350, "height cards are normalized to")
+	crop := flag.Bool("crop", false, "crop cards to the target aspect ratio instead of padding them")
+	source := flag.String("source", "", "local mirror directory or file:// URL to copy cards from instead of Wikimedia Commons")
+	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitConfigError
 	}
-	defer resp.Body.Close()
 
-	// Check status code
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	deck, ok := decks[*deckName]
+	if !ok {
+		fmt.Printf("Error: unknown deck %q (available: %s)\n", *deckName, strings.Join(deckNames(), ", "))
+		return exitConfigError
 	}
 
-	// Create the file
-	out, err := os.Create(filepath)
+	if *cardWidth <= 0 || *cardHeight <= 0 {
+		fmt.Println("Error: -card-width and -card-height must be positive")
+		return exitConfigError
+	}
+	normalizeOpts := NormalizeOptions{Width: *cardWidth, Height: *cardHeight, Crop: *crop}
+
+	audit := NewAuditLog(userAgent)
+
+	cache, err := NewCache(*cacheDir, *cacheTTL)
 	if err != nil {
-		return err
+		fmt.Println("Error creating cache directory:", err)
+		return exitConfigError
 	}
-	defer out.Close()
 
-	// Write the body to file
-	_, err = io.Copy(out, resp.Body)
-	return err
-}
+	// Resolve local mirror, if any, before touching the network
+	localDir := ""
+	if *source != "" {
+		localDir, err = parseLocalSource(*source)
+		if err != nil {
+			fmt.Println("Error:", err)
+			return exitConfigError
+		}
+	}
 
-func main() {
 	// Create directory to save images
-	dir := "../../src/assets/cards"
+	dir := path.Join(*outDir, deck.Name)
 	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
 		fmt.Println("Error creating directory:", err)
-		return
+		return exitConfigError
 	}
 
 	// Download playing cards
-	fmt.Println("=== Downloading Playing Cards ===")
+	fmt.Printf("=== Downloading Playing Cards (%s) ===\n", deck.Name)
 	suits := []string{"hearts", "diamonds", "clubs", "spades"}
 	ranks := []string{"ace", "2", "3", "4", "5", "6", "7", "8", "9", "10", "jack", "queen", "king"}
 
-	successCount := 0
-	failCount := 0
+	manifest := NewManifest(deck)
+	summary := &Summary{
+		Deck:      deck.Name,
+		Source:    "wikimedia",
+		OutputDir: dir,
+		Expected:  len(suits) * len(ranks),
+		Failures:  []Failure{},
+		Started:   audit.Started,
+	}
+	if localDir != "" {
+		summary.Source = localDir
+	}
+	fail := func(filename string, err error) {
+		summary.Failed++
+		summary.Failures = append(summary.Failures, Failure{File: filename, Error: err.Error()})
+	}
+	succeed := func(rank, suit, localPath, sourceURL string) {
+		if *normalize {
+			if err := normalizeFile(localPath, normalizeOpts); err != nil {
+				fmt.Printf("  Error: %v\n", err)
+				fail(path.Base(localPath), err)
+				return
+			}
+		}
+		if err := manifest.Add(rank, suit, localPath, sourceURL); err != nil {
+			fmt.Printf("  Error adding to manifest: %v\n", err)
+			fail(path.Base(localPath), err)
+			return
+		}
+		summary.Succeeded++
+	}
 
 	for _, suit := range suits {
 		for _, rank := range ranks {
-			// Byron Knoll uses lowercase for all ranks in the filename
-			filename := fmt.Sprintf("English_pattern_%s_of_%s.svg", rank, suit)
+			filename := deck.Filename(rank, suit)
 
 			fmt.Printf("Processing: %s\n", filename)
 
+			localPath := path.Join(dir, filename)
+
+			// Copy from local mirror - no network, no rate limiting needed
+			if localDir != "" {
+				if err := copyLocalFile(localDir, filename, localPath, audit); err != nil {
+					fmt.Printf("  Error copying: %v\n", err)
+					fail(filename, err)
+				} else {
+					fmt.Printf("  ✓ Copied from local source\n")
+					succeed(rank, suit, localPath, localFileURL(localDir, filename))
+				}
+				continue
+			}
+
 			// Get actual URL from Wikimedia API
-			imgURL, err := getImageURL(filename)
+			imgURL, err := getImageURL(filename, audit, cache)
 			if err != nil {
 				fmt.Printf("  Error getting URL: %v\n", err)
-				failCount++
-				time.Sleep(500 * time.Millisecond)
+				fail(filename, err)
 				continue
 			}
 
-			localPath := path.Join(dir, filename)
 			fmt.Printf("  Downloading from: %s\n", imgURL)
 
-			if err := downloadFile(imgURL, localPath); err != nil {
+			if err := downloadFile(imgURL, localPath, filename, audit, cache); err != nil {
 				fmt.Printf("  Error downloading: %v\n", err)
-				failCount++
+				fail(filename, err)
 			} else {
 				fmt.Printf("  ✓ Downloaded successfully\n")
-				successCount++
+				succeed(rank, suit, localPath, imgURL)
 			}
-
-			// Rate limiting - wait between requests to be respectful
-			time.Sleep(500 * time.Millisecond)
 		}
 	}
 
 	fmt.Printf("\n=== Download Summary ===\n")
-	fmt.Printf("Successfully downloaded: %d cards\n", successCount)
-	fmt.Printf("Failed: %d cards\n", failCount)
+	fmt.Printf("Successfully downloaded: %d cards\n", summary.Succeeded)
+	fmt.Printf("Failed: %d cards\n", summary.Failed)
 	fmt.Printf("Cards saved to: %s\n", dir)
+
+	if manifestPath, err := manifest.WriteFile(dir); err != nil {
+		fmt.Println("Error writing manifest:", err)
+	} else {
+		fmt.Printf("Manifest written to: %s\n", manifestPath)
+	}
+
+	if logPath, err := audit.WriteFile(*auditDir); err != nil {
+		fmt.Println("Error writing audit log:", err)
+	} else {
+		fmt.Printf("Audit log written to: %s\n", logPath)
+		summary.AuditLog = logPath
+	}
+
+	// The exit code still reports the download result if the summary can't be written
+	summary.finish()
+	if err := summary.WriteFile(*summaryPath); err != nil {
+		fmt.Println("Error writing summary:", err)
+	} else {
+		fmt.Printf("Summary written to: %s (result: %s)\n", *summaryPath, summary.Result)
+	}
+
+	return summary.ExitCode
 }
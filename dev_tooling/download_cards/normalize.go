@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// NormalizeOptions sets the size every card SVG is normalized to
+type NormalizeOptions struct {
+	Width  float64
+	Height float64
+	Crop   bool // crop the longer side to fit instead of padding the shorter one
+}
+
+// Pixels per unit for the length units found in SVG width/height attributes
+var unitScale = map[string]float64{
+	"":   1,
+	"px": 1,
+	"pt": 96.0 / 72.0,
+	"pc": 16,
+	"mm": 96.0 / 25.4,
+	"cm": 96.0 / 2.54,
+	"in": 96,
+}
+
+var lengthPattern = regexp.MustCompile(`^\s*([0-9]*\.?[0-9]+(?:[eE][-+]?[0-9]+)?)\s*([a-z]*)\s*$`)
+
+// Helper function to parse an SVG length such as "360", "540px" or "63mm" into pixels
+func parseLength(s string) (float64, error) {
+	m := lengthPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("unsupported length %q", s)
+	}
+	scale, ok := unitScale[m[2]]
+	if !ok {
+		return 0, fmt.Errorf("unsupported unit in length %q", s)
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, err
+	}
+	return v * scale, nil
+}
+
+// Helper function to locate the root <svg> start tag, returning its byte range
+func findRootTag(data []byte) (start, end int, attrs map[string]string, err error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Strict = false
+	for {
+		offset := int(d.InputOffset())
+		tok, err := d.RawToken()
+		if err == io.EOF {
+			return 0, 0, nil, fmt.Errorf("no <svg> element found")
+		}
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if se.Name.Local != "svg" {
+			return 0, 0, nil, fmt.Errorf("root element is <%s>, not <svg>", se.Name.Local)
+		}
+		attrs := map[string]string{}
+		for _, a := range se.Attr {
+			// Only unprefixed attributes; sodipodi:/inkscape: copies are ignored
+			if a.Name.Space == "" {
+				attrs[a.Name.Local] = a.Value
+			}
+		}
+		return offset, int(d.InputOffset()), attrs, nil
+	}
+}
+
+// Helper function to set an attribute on a raw start tag, adding it if missing
+func setAttr(tag, name, value string) string {
+	re := regexp.MustCompile(`(\s)` + regexp.QuoteMeta(name) + `\s*=\s*("[^"]*"|'[^']*')`)
+	attr := fmt.Sprintf(`%s="%s"`, name, value)
+	if re.MatchString(tag) {
+		return re.ReplaceAllLiteralString(tag, " "+attr)
+	}
+	closing := ">"
+	if strings.HasSuffix(tag, "/>") {
+		closing = "/>"
+	}
+	return strings.TrimSuffix(tag, closing) + " " + attr + closing
+}
+
+// Helper function to format a coordinate to 4 decimal places without trailing zeros
+func formatNum(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1e4)/1e4, 'f', -1, 64)
+}
+
+// normalizeSVG rewrites the root element so the card has the target
+// dimensions and aspect ratio. The existing drawing is centered and padded
+// (or cropped) by adjusting the viewBox; the rest of the document is untouched.
+func normalizeSVG(data []byte, opts NormalizeOptions) ([]byte, error) {
+	start, end, attrs, err := findRootTag(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Current drawing area in user units, from viewBox or width/height
+	var minX, minY, w, h float64
+	if vb, ok := attrs["viewBox"]; ok {
+		fields := strings.FieldsFunc(vb, func(r rune) bool { return r == ' ' || r == ',' })
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("malformed viewBox %q", vb)
+		}
+		nums := make([]float64, 4)
+		for i, f := range fields {
+			if nums[i], err = strconv.ParseFloat(f, 64); err != nil {
+				return nil, fmt.Errorf("malformed viewBox %q", vb)
+			}
+		}
+		minX, minY, w, h = nums[0], nums[1], nums[2], nums[3]
+	} else {
+		if w, err = parseLength(attrs["width"]); err != nil {
+			return nil, fmt.Errorf("no viewBox and bad width: %w", err)
+		}
+		if h, err = parseLength(attrs["height"]); err != nil {
+			return nil, fmt.Errorf("no viewBox and bad height: %w", err)
+		}
+	}
+	if w <= 0 || h <= 0 {
+		return nil, fmt.Errorf("empty drawing area %gx%g", w, h)
+	}
+
+	// Grow (pad) or shrink (crop) one side, keeping the drawing centered
+	target := opts.Width / opts.Height
+	if (w/h > target) != opts.Crop {
+		newH := w / target
+		minY -= (newH - h) / 2
+		h = newH
+	} else {
+		newW := h * target
+		minX -= (newW - w) / 2
+		w = newW
+	}
+
+	tag := string(data[start:end])
+	tag = setAttr(tag, "viewBox", strings.Join([]string{formatNum(minX), formatNum(minY), formatNum(w), formatNum(h)}, " "))
+	tag = setAttr(tag, "width", formatNum(opts.Width))
+	tag = setAttr(tag, "height", formatNum(opts.Height))
+	tag = setAttr(tag, "preserveAspectRatio", "xMidYMid meet")
+
+	var out bytes.Buffer
+	out.Write(data[:start])
+	out.WriteString(tag)
+	out.Write(data[end:])
+	return out.Bytes(), nil
+}
+
+// Helper function to normalize a card file in place
+func normalizeFile(localPath string, opts NormalizeOptions) error {
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		return err
+	}
+	out, err := normalizeSVG(data, opts)
+	if err != nil {
+		return fmt.Errorf("normalizing %s: %w", localPath, err)
+	}
+	return os.WriteFile(localPath, out, 0o644)
+}
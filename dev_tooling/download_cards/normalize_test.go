@@ -0,0 +1,142 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseLength(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{in: "360", want: 360},
+		{in: "540px", want: 540},
+		{in: " 12.5 ", want: 12.5},
+		{in: "1e2", want: 100},
+		{in: "72pt", want: 96},
+		{in: "6pc", want: 96},
+		{in: "25.4mm", want: 96},
+		{in: "2.54cm", want: 96},
+		{in: "1in", want: 96},
+		{in: "10em", wantErr: true},
+		{in: "100%", wantErr: true},
+		{in: "-5", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseLength(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseLength(%q) = %g, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseLength(%q): %v", tt.in, err)
+			continue
+		}
+		if formatNum(got) != formatNum(tt.want) {
+			t.Errorf("parseLength(%q) = %g, want %g", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeSVG(t *testing.T) {
+	card := NormalizeOptions{Width: 250, Height: 350}
+	cropped := NormalizeOptions{Width: 250, Height: 350, Crop: true}
+
+	tests := []struct {
+		name    string
+		in      string
+		opts    NormalizeOptions
+		viewBox string
+		keep    string // must survive unchanged
+	}{
+		{
+			name:    "width and height only, too narrow: pad the sides",
+			in:      `<svg xmlns="http://www.w3.org/2000/svg" width="360" height="540"><g/></svg>`,
+			opts:    card,
+			viewBox: "-12.8571 0 385.7143 540",
+			keep:    "<g/>",
+		},
+		{
+			name:    "viewBox, too wide: pad top and bottom",
+			in:      `<svg viewBox="0 0 100 100" width="1in" height="1in"/>`,
+			opts:    card,
+			viewBox: "0 -20 100 140",
+		},
+		{
+			name:    "viewBox with commas, too wide: crop the sides",
+			in:      `<svg viewBox="0,0,100,100"/>`,
+			opts:    cropped,
+			viewBox: "14.2857 0 71.4286 100",
+		},
+		{
+			name:    "already the right ratio",
+			in:      `<svg viewBox="10 20 250 350"/>`,
+			opts:    card,
+			viewBox: "10 20 250 350",
+		},
+		{
+			name:    "physical units",
+			in:      `<svg width="63mm" height="88mm"/>`,
+			opts:    card,
+			viewBox: "0 -0.378 238.1102 333.3543",
+		},
+		{
+			name:    "prefixed attributes are left alone",
+			in:      `<svg xmlns:sodipodi="http://sodipodi.sourceforge.net/DTD/sodipodi-0.dtd" sodipodi:width="1" width="250" height="350" preserveAspectRatio="none"/>`,
+			opts:    card,
+			viewBox: "0 0 250 350",
+			keep:    `sodipodi:width="1"`,
+		},
+	}
+
+	for _, tt := range tests {
+		out, err := normalizeSVG([]byte(tt.in), tt.opts)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		_, _, attrs, err := findRootTag(out)
+		if err != nil {
+			t.Errorf("%s: output unreadable: %v", tt.name, err)
+			continue
+		}
+		want := map[string]string{
+			"viewBox":             tt.viewBox,
+			"width":               "250",
+			"height":              "350",
+			"preserveAspectRatio": "xMidYMid meet",
+		}
+		for name, value := range want {
+			if attrs[name] != value {
+				t.Errorf("%s: %s = %q, want %q", tt.name, name, attrs[name], value)
+			}
+		}
+		if tt.keep != "" && !strings.Contains(string(out), tt.keep) {
+			t.Errorf("%s: %q was lost from %s", tt.name, tt.keep, out)
+		}
+	}
+}
+
+func TestNormalizeSVGErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"not an svg", `<html/>`},
+		{"no root element", `<?xml version="1.0"?>`},
+		{"malformed viewBox", `<svg viewBox="0 0 100"/>`},
+		{"no viewBox or size", `<svg/>`},
+		{"unsupported unit", `<svg width="10em" height="14em"/>`},
+		{"empty drawing area", `<svg viewBox="0 0 0 100"/>`},
+	}
+	for _, tt := range tests {
+		if _, err := normalizeSVG([]byte(tt.in), NormalizeOptions{Width: 250, Height: 350}); err == nil {
+			t.Errorf("%s: want an error", tt.name)
+		}
+	}
+}
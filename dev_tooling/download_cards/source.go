@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Helper function to resolve a -source value to a local mirror directory.
+// Accepts a plain directory path or a file:// URL.
+func parseLocalSource(source string) (string, error) {
+	dir := source
+	if u, err := url.Parse(source); err == nil && u.Scheme == "file" {
+		if u.Host != "" && u.Host != "localhost" {
+			return "", fmt.Errorf("file URL with remote host not supported: %s", source)
+		}
+		dir = u.Path
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", fmt.Errorf("local source: %w", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("local source is not a directory: %s", dir)
+	}
+	return dir, nil
+}
+
+// Helper function to build an absolute file:// URL for a card in a local mirror,
+// so relative mirror paths aren't mistaken for a host
+func localFileURL(srcDir, filename string) string {
+	srcPath := filepath.Join(srcDir, filename)
+	absPath, err := filepath.Abs(srcPath)
+	if err != nil {
+		absPath = srcPath
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(absPath)}).String()
+}
+
+// Helper function to copy a card from a local mirror directory
+func copyLocalFile(srcDir, filename, localPath string, audit *AuditLog) (err error) {
+	srcPath := filepath.Join(srcDir, filename)
+	fileURL := localFileURL(srcDir, filename)
+
+	entry := AuditEntry{Time: time.Now().UTC(), Kind: "local", File: filename, URL: fileURL}
+	start := time.Now()
+	defer func() {
+		entry.DurationMs = time.Since(start).Milliseconds()
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		audit.Record(entry)
+	}()
+
+	in, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(localPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	entry.Bytes, err = io.Copy(out, in)
+	return err
+}
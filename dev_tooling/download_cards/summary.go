@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Exit codes, so CI can gate releases on a complete asset set
+const (
+	exitOK           = 0 // every card fetched
+	exitPartial      = 1 // some cards failed
+	exitTotalFailure = 2 // no cards fetched
+	exitConfigError  = 3 // bad flags or unusable directories; nothing attempted
+)
+
+// Failure records why a single card could not be fetched
+type Failure struct {
+	File  string `json:"file"`
+	Error string `json:"error"`
+}
+
+// Summary is the machine-readable result of a run
+type Summary struct {
+	Result    string    `json:"result"` // "ok", "partial" or "failed"
+	ExitCode  int       `json:"exit_code"`
+	Deck      string    `json:"deck"`
+	Source    string    `json:"source"`
+	OutputDir string    `json:"output_dir"`
+	Expected  int       `json:"expected"`
+	Succeeded int       `json:"succeeded"`
+	Failed    int       `json:"failed"`
+	Failures  []Failure `json:"failures"`
+	AuditLog  string    `json:"audit_log,omitempty"`
+	Started   time.Time `json:"started"`
+	Finished  time.Time `json:"finished"`
+}
+
+// Helper function to work out the overall result from the counts
+func (s *Summary) finish() {
+	s.Finished = time.Now().UTC()
+	switch {
+	case s.Failed == 0:
+		s.Result, s.ExitCode = "ok", exitOK
+	case s.Succeeded == 0:
+		s.Result, s.ExitCode = "failed", exitTotalFailure
+	default:
+		s.Result, s.ExitCode = "partial", exitPartial
+	}
+}
+
+// WriteFile writes the summary as JSON to path
+func (s *Summary) WriteFile(path string) error {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return err
+		}
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
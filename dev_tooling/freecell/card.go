@@ -0,0 +1,64 @@
+package main
+
+import "fmt"
+
+// Suit of a card, in Microsoft FreeCell deck order (clubs, diamonds, hearts, spades)
+type Suit int
+
+const (
+	Clubs Suit = iota
+	Diamonds
+	Hearts
+	Spades
+)
+
+// Suit names match the frontend's Suit type
+var suitNames = [...]string{"clubs", "diamonds", "hearts", "spades"}
+var suitLetters = [...]string{"C", "D", "H", "S"}
+var suitSymbols = [...]string{"♣", "♦", "♥", "♠"}
+
+func (s Suit) String() string { return suitNames[s] }
+
+// Symbol returns the suit's symbol, e.g. "♥"
+func (s Suit) Symbol() string { return suitSymbols[s] }
+
+// IsRed reports whether the suit is hearts or diamonds
+func (s Suit) IsRed() bool { return s == Diamonds || s == Hearts }
+
+// ColorClass returns the CSS class the frontend's Card component uses for the
+// suit's color (defined in frontend/src/components/Card.css)
+func (s Suit) ColorClass() string {
+	if s.IsRed() {
+		return "card-red"
+	}
+	return "card-black"
+}
+
+// Rank of a card, Ace=1 to King=13
+type Rank int
+
+// Rank names match the frontend's Rank type
+var rankNames = [...]string{"", "ace", "2", "3", "4", "5", "6", "7", "8", "9", "10", "jack", "queen", "king"}
+var rankLetters = [...]string{"", "A", "2", "3", "4", "5", "6", "7", "8", "9", "T", "J", "Q", "K"}
+var rankLabels = [...]string{"", "A", "2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K"}
+var rankWords = [...]string{"", "ace", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten", "jack", "queen", "king"}
+
+func (r Rank) String() string { return rankNames[r] }
+
+// Card is a single playing card
+type Card struct {
+	Rank Rank
+	Suit Suit
+}
+
+// Code returns standard two-letter notation, e.g. "TH" for the ten of hearts
+func (c Card) Code() string { return rankLetters[c.Rank] + suitLetters[c.Suit] }
+
+// Label returns the human-readable form, e.g. "10♥"
+func (c Card) Label() string { return rankLabels[c.Rank] + suitSymbols[c.Suit] }
+
+// Name returns the accessible spoken form, e.g. "ten of hearts"
+func (c Card) Name() string { return rankWords[c.Rank] + " of " + suitNames[c.Suit] }
+
+// ID returns the frontend's card identifier, e.g. "10_of_hearts"
+func (c Card) ID() string { return fmt.Sprintf("%s_of_%s", c.Rank, c.Suit) }
@@ -0,0 +1,71 @@
+package main
+
+// Board layout
+const (
+	tableauColumns = 8
+	freeCellCount  = 4
+	deckSize       = 52
+)
+
+// Deal numbers accepted, matching the frontend's game number selector
+const (
+	minDealNumber = 1
+	maxDealNumber = 1000000
+)
+
+// Helper function to build the deck in Microsoft FreeCell order:
+// card index = rank * 4 + suit, with suits in CDHS order
+func newDeck() []Card {
+	deck := make([]Card, 0, deckSize)
+	for rank := Rank(1); rank <= 13; rank++ {
+		for suit := Clubs; suit <= Spades; suit++ {
+			deck = append(deck, Card{Rank: rank, Suit: suit})
+		}
+	}
+	return deck
+}
+
+// shuffle applies the Microsoft FreeCell shuffle for dealNum.
+// This mirrors frontend/src/utils/freecellRng.ts exactly.
+func shuffle(deck []Card, dealNum uint32) []Card {
+	out := make([]Card, len(deck))
+	copy(out, deck)
+
+	seed := dealNum
+	for i := 0; i < len(out); i++ {
+		cardsLeft := uint32(len(out) - i)
+
+		// Microsoft's LCG
+		seed = seed*214013 + 2531011
+		rand := (seed >> 16) & 0x7fff
+
+		// Special handling for large deal numbers
+		var rect uint32
+		if dealNum < 0x80000000 {
+			rect = rand % cardsLeft
+		} else {
+			rect = (rand | 0x8000) % cardsLeft
+		}
+
+		out[rect], out[cardsLeft-1] = out[cardsLeft-1], out[rect]
+	}
+
+	// Reverse to get final order
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+// Deal returns the eight tableau columns for a game, dealt in row-major
+// order: the first 4 columns get 7 cards, the last 4 get 6
+func Deal(dealNum uint32) [tableauColumns][]Card {
+	cards := shuffle(newDeck(), dealNum)
+
+	var tableau [tableauColumns][]Card
+	for i, card := range cards {
+		col := i % tableauColumns
+		tableau[col] = append(tableau[col], card)
+	}
+	return tableau
+}
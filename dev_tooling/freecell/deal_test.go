@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// Helper function to join a column's card codes, e.g. "JD KD 2S"
+func columnCodes(col []Card) string {
+	codes := make([]string, len(col))
+	for i, card := range col {
+		codes[i] = card.Code()
+	}
+	return strings.Join(codes, " ")
+}
+
+func TestDealKnownGames(t *testing.T) {
+	tests := []struct {
+		deal    uint32
+		columns [tableauColumns]string
+	}{
+		{
+			// First row: JD 2D 9H JC 5D 7H 7C 5H
+			deal: 1,
+			columns: [tableauColumns]string{
+				"JD KD 2S 4C 3S 6D 6S",
+				"2D KC KS 5C TD 8S 9C",
+				"9H 9S 9D TS 4S 8D 2H",
+				"JC 5S QD QH TH QS 6H",
+				"5D AD JS 4H 8H 6C",
+				"7H QC AS AC 2C 3D",
+				"7C KH AH 4D JH 8C",
+				"5H 3H 3C 7S 7D TC",
+			},
+		},
+		{
+			// First row: 7D AD 5C 3S 5S 8C 2D AH
+			deal: 617,
+			columns: [tableauColumns]string{
+				"7D TD TH KD 4C 4S JD",
+				"AD 7S QC 5H QS TS KS",
+				"5C QD 3H 9S 9C 2H KC",
+				"3S AC 9D 3C 9H 5D 4H",
+				"5S 6D 6S 8S 7C JC",
+				"8C 8H 8D 7H 6H 6C",
+				"2D AS 3D 4D 2C JH",
+				"AH KH TC JS 2S QH",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tableau := Deal(tt.deal)
+		for col, want := range tt.columns {
+			if got := columnCodes(tableau[col]); got != want {
+				t.Errorf("deal %d column %d = %q, want %q", tt.deal, col+1, got, want)
+			}
+		}
+	}
+}
+
+func TestDealUsesWholeDeck(t *testing.T) {
+	seen := map[Card]bool{}
+	for _, col := range Deal(11982) {
+		for _, card := range col {
+			if seen[card] {
+				t.Fatalf("card %s dealt twice", card.Code())
+			}
+			seen[card] = true
+		}
+	}
+	if len(seen) != deckSize {
+		t.Errorf("dealt %d cards, want %d", len(seen), deckSize)
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// Output formats for the deal command
+var dealFormats = []string{"text", "fcsolve", "json"}
+
+// Helper function to print a position's tableau in fc-solve board format:
+// one line per column, top card last
+func writeFCSolve(w io.Writer, p Position) error {
+	var b strings.Builder
+	for _, column := range p.Tableau {
+		codes := make([]string, len(column))
+		for i, card := range column {
+			codes[i] = card.Code()
+		}
+		b.WriteString(strings.Join(codes, " ") + "\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Helper function to print a position as JSON in the frontend's GameState shape
+func writeJSON(w io.Writer, gameNumber uint32, p Position) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p.toGameState(gameNumber))
+}
@@ -0,0 +1,3 @@
+module github.com/joshuamkite/freecell/freecell
+
+go 1.25.5
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Check verifies the invariants every position must keep: the foundations
+// each hold 0 to 13 cards, and the 52 cards each appear exactly once across
+// the tableau, free cells and foundations
+func (p *Position) Check() error {
+	var errs []error
+	seen := map[Card]string{}
+	place := func(c Card, where string) {
+		switch {
+		case c.Rank < 1 || c.Rank > 13 || c.Suit < Clubs || c.Suit > Spades:
+			errs = append(errs, fmt.Errorf("invalid card %+v in %s", c, where))
+		case seen[c] != "":
+			errs = append(errs, fmt.Errorf("%s is in both %s and %s", c.Code(), seen[c], where))
+		default:
+			seen[c] = where
+		}
+	}
+
+	for s, rank := range p.Foundations {
+		if rank < 0 || rank > 13 {
+			errs = append(errs, fmt.Errorf("%s foundation has %d cards", Suit(s), rank))
+			continue
+		}
+		for r := Rank(1); r <= rank; r++ {
+			place(Card{Rank: r, Suit: Suit(s)}, Suit(s).String()+" foundation")
+		}
+	}
+	for i, c := range p.FreeCells {
+		if c != nil {
+			place(*c, fmt.Sprintf("free cell %d", i+1))
+		}
+	}
+	for i, column := range p.Tableau {
+		for _, c := range column {
+			place(c, fmt.Sprintf("column %d", i+1))
+		}
+	}
+
+	if len(seen) != 52 {
+		errs = append(errs, fmt.Errorf("%d distinct cards on the board, want 52", len(seen)))
+	}
+	return errors.Join(errs...)
+}
+
+// Helper function to verify next is what playing m from p should give:
+// next keeps every invariant, the foundations only grow, and only by the
+// moved card, and cards moved to the tableau extend a descending run of
+// alternating colors
+func checkMove(p Position, m Move, next Position) error {
+	if err := next.Check(); err != nil {
+		return err
+	}
+
+	for s := range p.Foundations {
+		want := p.Foundations[s]
+		if m.To == FoundationPile && m.ToIndex == s {
+			want++
+		}
+		if next.Foundations[s] != want {
+			return fmt.Errorf("%s foundation went from %d to %d cards", Suit(s), p.Foundations[s], next.Foundations[s])
+		}
+	}
+
+	if m.To == TableauPile {
+		column := next.Tableau[m.ToIndex]
+		if len(column) != len(p.Tableau[m.ToIndex])+m.Count {
+			return fmt.Errorf("column %d went from %d to %d cards moving %d", m.ToIndex+1, len(p.Tableau[m.ToIndex]), len(column), m.Count)
+		}
+		// The moved cards, plus the card they landed on if any
+		start := max(0, len(column)-m.Count-1)
+		for i := start + 1; i < len(column); i++ {
+			if !canMoveToTableau(column[i], column[i-1:i]) {
+				return fmt.Errorf("column %d has %s on %s", m.ToIndex+1, column[i].Code(), column[i-1].Code())
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyChecked is Apply for debugging: it verifies the move and the position
+// it leads to, and panics with both positions drawn out if an invariant is
+// broken. Use it when changing the move rules or fuzzing with simulate -check.
+func (p Position) ApplyChecked(m Move) Position {
+	next := p.Apply(m)
+	if err := checkMove(p, m, next); err != nil {
+		panic(invariantDump(err, p, m, next))
+	}
+	return next
+}
+
+// Helper function to apply moves in order with ApplyChecked
+func applyAllChecked(p Position, moves []Move) Position {
+	for _, m := range moves {
+		p = p.ApplyChecked(m)
+	}
+	return p
+}
+
+// Helper function to describe an invariant violation with the positions
+// before and after the move, as diagrams and as keys
+func invariantDump(err error, before Position, m Move, after Position) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "freecell: invariant violated by move %v:\n%v\n\n", m, err)
+	o := RenderOptions{Layout: "compact", ASCII: true}
+	renderPosition(&b, "Before:", before, o)
+	fmt.Fprintf(&b, "key %s\n\n", before.Key())
+	renderPosition(&b, "After:", after, o)
+	fmt.Fprintf(&b, "key %s\n", after.Key())
+	return b.String()
+}
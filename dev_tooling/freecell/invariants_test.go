@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(p *Position)
+		want   string // part of the error; "" for a valid position
+	}{
+		{"starting position", func(p *Position) {}, ""},
+		{"card in a free cell", func(p *Position) {
+			c := p.Tableau[0][len(p.Tableau[0])-1]
+			p.Tableau[0] = p.Tableau[0][:len(p.Tableau[0])-1]
+			p.FreeCells[2] = &c
+		}, ""},
+		{"duplicate card", func(p *Position) { p.Tableau[0][0] = p.Tableau[1][0] }, "is in both"},
+		{"missing card", func(p *Position) { p.Tableau[0] = p.Tableau[0][1:] }, "51 distinct cards"},
+		{"card on a foundation and in the tableau", func(p *Position) { p.Foundations[Clubs] = 1 }, "AC is in both clubs foundation and"},
+		{"overfull foundation", func(p *Position) { p.Foundations[Hearts] = 14 }, "hearts foundation has 14 cards"},
+		{"invalid card", func(p *Position) { p.Tableau[3][0].Rank = 0 }, "invalid card"},
+	}
+
+	for _, tt := range tests {
+		p := NewPosition(Deal(1))
+		tt.modify(&p)
+		err := p.Check()
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("%s: error = %v, want one containing %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestCheckMoveAcceptsLegalMoves(t *testing.T) {
+	for deal := uint32(1); deal <= 5; deal++ {
+		p := NewPosition(Deal(deal))
+		for _, m := range p.LegalMoves() {
+			if err := checkMove(p, m, p.Apply(m)); err != nil {
+				t.Errorf("deal %d: legal move %v rejected: %v", deal, m, err)
+			}
+		}
+	}
+}
+
+// Helper function to get the panic message from calling f, or "" if it didn't panic
+func panicMessage(f func()) (msg string) {
+	defer func() {
+		if r := recover(); r != nil {
+			msg = fmt.Sprint(r)
+		}
+	}()
+	f()
+	return ""
+}
+
+func TestApplyCheckedPanicsOnIllegalMoves(t *testing.T) {
+	p := NewPosition(Deal(1))
+
+	// A card put on one it can't go on
+	var illegal Move
+	for to := 1; to < tableauColumns; to++ {
+		top := p.Tableau[0][len(p.Tableau[0])-1]
+		if !canMoveToTableau(top, p.Tableau[to]) {
+			illegal = Move{From: TableauPile, FromIndex: 0, To: TableauPile, ToIndex: to, Count: 1}
+			break
+		}
+	}
+	if illegal.ToIndex == 0 {
+		t.Fatal("deal 1 has no illegal tableau move from column 1")
+	}
+
+	// A card sent to its foundation out of order
+	var early Move
+	for from, column := range p.Tableau {
+		if top := column[len(column)-1]; top.Rank > 1 {
+			early = Move{From: TableauPile, FromIndex: from, To: FoundationPile, ToIndex: int(top.Suit), Count: 1}
+			break
+		}
+	}
+
+	for _, m := range []Move{illegal, early} {
+		msg := panicMessage(func() { p.ApplyChecked(m) })
+		if msg == "" {
+			t.Errorf("ApplyChecked(%v) didn't panic", m)
+			continue
+		}
+		for _, want := range []string{"invariant violated", "Before:", "After:", "key " + p.Key()} {
+			if !strings.Contains(msg, want) {
+				t.Errorf("ApplyChecked(%v) panic is missing %q:\n%s", m, want, msg)
+			}
+		}
+	}
+}
+
+func TestSimulateWithChecks(t *testing.T) {
+	opts := SimulateOptions{FirstDeal: 1, Deals: 10, MaxMoves: 500, Seed: 1, AutoPlay: true, Check: true}
+	for _, newPolicy := range policies {
+		policy := newPolicy()
+		kinds := map[string]int{}
+		for deal := opts.FirstDeal; deal < opts.FirstDeal+uint32(opts.Deals); deal++ {
+			if msg := panicMessage(func() { playGame(deal, policy, opts, kinds) }); msg != "" {
+				t.Fatalf("%s: %s", policy.Name(), msg)
+			}
+		}
+	}
+}
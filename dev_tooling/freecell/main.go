@@ -0,0 +1,253 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Exit codes
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// Command is a freecell subcommand
+type Command struct {
+	Summary string
+	Run     func(args []string) int
+}
+
+// Available subcommands, keyed by name
+var commands = map[string]Command{
+	"deal":     {Summary: "print the board for a deal number", Run: runDeal},
+	"render":   {Summary: "draw any position (GameState JSON) as text", Run: runRender},
+	"simulate": {Summary: "play deals headlessly with move policies and report win rates", Run: runSimulate},
+}
+
+// Helper function to print top-level usage
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: freecell <command> [flags] [args]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", name, commands[name].Summary)
+	}
+}
+
+// Helper function to parse a deal number argument
+func parseDealNumber(s string) (uint32, error) {
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil || n < minDealNumber || n > maxDealNumber {
+		return 0, fmt.Errorf("invalid deal number %q: must be %d-%d", s, minDealNumber, maxDealNumber)
+	}
+	return uint32(n), nil
+}
+
+// Helper function to register the text rendering flags on a command
+func renderFlags(fs *flag.FlagSet) *RenderOptions {
+	o := &RenderOptions{}
+	fs.StringVar(&o.Layout, "layout", "compact", "text layout ("+strings.Join(renderLayouts, ", ")+")")
+	fs.BoolVar(&o.ASCII, "ascii", false, "use plain ASCII card codes (TH) instead of suit symbols (10♥)")
+	fs.BoolVar(&o.Color, "color", false, "color red suits with ANSI escape codes")
+	return o
+}
+
+// Helper function to check rendering flags after parsing
+func checkRenderFlags(o *RenderOptions) bool {
+	if !slices.Contains(renderLayouts, o.Layout) {
+		fmt.Fprintf(os.Stderr, "Error: unknown layout %q (available: %s)\n", o.Layout, strings.Join(renderLayouts, ", "))
+		return false
+	}
+	return true
+}
+
+// freecell deal [-format text|fcsolve|json] [-layout compact|spread] [-ascii] [-color] <number>
+func runDeal(args []string) int {
+	fs := flag.NewFlagSet("deal", flag.ContinueOnError)
+	format := fs.String("format", "text", "output format ("+strings.Join(dealFormats, ", ")+")")
+	opts := renderFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: freecell deal [flags] <number>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+	if !slices.Contains(dealFormats, *format) {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (available: %s)\n", *format, strings.Join(dealFormats, ", "))
+		return exitUsage
+	}
+	if !checkRenderFlags(opts) {
+		return exitUsage
+	}
+
+	dealNum, err := parseDealNumber(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return exitUsage
+	}
+	pos := NewPosition(Deal(dealNum))
+
+	switch *format {
+	case "fcsolve":
+		err = writeFCSolve(os.Stdout, pos)
+	case "json":
+		err = writeJSON(os.Stdout, dealNum, pos)
+	default:
+		err = renderPosition(os.Stdout, fmt.Sprintf("Deal #%d", dealNum), pos, *opts)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// freecell render [-layout compact|spread] [-ascii] [-color] [file]
+func runRender(args []string) int {
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	opts := renderFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: freecell render [flags] [file]")
+		fmt.Fprintln(os.Stderr, "Reads a position as GameState JSON from file, or stdin if no file is given.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return exitUsage
+	}
+	if !checkRenderFlags(opts) {
+		return exitUsage
+	}
+
+	in := os.Stdin
+	if fs.NArg() == 1 && fs.Arg(0) != "-" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			return exitError
+		}
+		defer f.Close()
+		in = f
+	}
+
+	pos, gameNumber, err := readPosition(in)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return exitError
+	}
+	title := ""
+	if gameNumber != 0 {
+		title = fmt.Sprintf("Deal #%d", gameNumber)
+	}
+	if err := renderPosition(os.Stdout, title, pos, *opts); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// freecell simulate [-n deals] [-start deal] [-policy random,greedy] [-max-moves n] [-seed n] [-json] [-check]
+func runSimulate(args []string) int {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	n := fs.Int("n", 100, "number of deals to play")
+	start := fs.String("start", "1", "first deal number")
+	policyList := fs.String("policy", "random,greedy", "comma-separated policies ("+strings.Join(policyNames(), ", ")+")")
+	maxMoves := fs.Int("max-moves", 1000, "give up on a game after this many moves")
+	seed := fs.Uint64("seed", 1, "random seed, so runs are reproducible")
+	autoPlay := fs.Bool("autoplay", true, "auto-play safe cards to the foundations after each move, as the game does")
+	asJSON := fs.Bool("json", false, "print results as JSON")
+	perGame := fs.Bool("games", false, "include every game's result in JSON output")
+	check := fs.Bool("check", false, "verify invariants after every move and panic with a dump of the board on a violation (slower; for debugging)")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: freecell simulate [flags]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	first, err := parseDealNumber(*start)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return exitUsage
+	}
+	if *n < 1 || uint64(first)+uint64(*n)-1 > maxDealNumber {
+		fmt.Fprintf(os.Stderr, "Error: -n must be at least 1 and the last deal at most %d\n", maxDealNumber)
+		return exitUsage
+	}
+	if *maxMoves < 1 {
+		fmt.Fprintln(os.Stderr, "Error: -max-moves must be at least 1")
+		return exitUsage
+	}
+	pols, err := parsePolicies(*policyList)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return exitUsage
+	}
+
+	opts := SimulateOptions{
+		FirstDeal: first,
+		Deals:     *n,
+		MaxMoves:  *maxMoves,
+		Seed:      *seed,
+		AutoPlay:  *autoPlay,
+		KeepGames: *asJSON && *perGame,
+		Check:     *check,
+	}
+	reports := simulate(pols, opts)
+
+	if *asJSON {
+		err = writeSimulationJSON(os.Stdout, reports)
+	} else {
+		err = writeSimulationText(os.Stdout, opts, reports)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return exitError
+	}
+	return exitOK
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(exitUsage)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		if os.Args[1] == "-h" || os.Args[1] == "-help" || os.Args[1] == "help" {
+			usage()
+			os.Exit(exitOK)
+		}
+		fmt.Fprintf(os.Stderr, "Error: unknown command %q\n\n", os.Args[1])
+		usage()
+		os.Exit(exitUsage)
+	}
+	os.Exit(cmd.Run(os.Args[2:]))
+}
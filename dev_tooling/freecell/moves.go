@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Pile identifies where a card sits on the board
+type Pile int
+
+const (
+	TableauPile Pile = iota
+	FreeCellPile
+	FoundationPile
+)
+
+var pileNames = [...]string{"tableau", "freecell", "foundation"}
+
+func (p Pile) String() string { return pileNames[p] }
+
+// Move is a single legal play. For tableau-to-tableau moves Count may be
+// more than one card; every other move carries exactly one card.
+type Move struct {
+	From      Pile
+	FromIndex int
+	To        Pile
+	ToIndex   int // for foundations, the suit
+	Count     int
+}
+
+// Kind names the move for statistics, e.g. "tableau->foundation"
+func (m Move) Kind() string { return m.From.String() + "->" + m.To.String() }
+
+func (m Move) String() string {
+	return fmt.Sprintf("%s %d -> %s %d (%d)", m.From, m.FromIndex+1, m.To, m.ToIndex+1, m.Count)
+}
+
+// Helper function to check a card can go on top of a tableau column:
+// any card on an empty column, otherwise one rank lower and opposite color
+func canMoveToTableau(c Card, column []Card) bool {
+	if len(column) == 0 {
+		return true
+	}
+	top := column[len(column)-1]
+	return c.Rank == top.Rank-1 && c.Suit.IsRed() != top.Suit.IsRed()
+}
+
+// Helper function to check a card is the next one for its foundation
+func (p *Position) canMoveToFoundation(c Card) bool {
+	return c.Rank == p.Foundations[c.Suit]+1
+}
+
+// Helper function to count empty free cells and tableau columns
+func (p *Position) empties() (freeCells, columns int) {
+	for _, c := range p.FreeCells {
+		if c == nil {
+			freeCells++
+		}
+	}
+	for _, column := range p.Tableau {
+		if len(column) == 0 {
+			columns++
+		}
+	}
+	return freeCells, columns
+}
+
+// Helper function to get the largest sequence that can move as a unit.
+// Mirrors the frontend's canMoveCardSequence, which always leaves one
+// empty column out of the count.
+func maxMovableCards(emptyFreeCells, emptyColumns int) int {
+	return (1 + emptyFreeCells) << max(0, emptyColumns-1)
+}
+
+// IsWon reports whether every card is on the foundations
+func (p *Position) IsWon() bool {
+	return p.Foundations == [4]Rank{13, 13, 13, 13}
+}
+
+// LegalMoves lists every legal move from p. Moves to empty free cells and
+// empty columns only target the first empty slot, since the others are equivalent.
+func (p *Position) LegalMoves() []Move {
+	var moves []Move
+
+	firstFreeCell := -1
+	for i, c := range p.FreeCells {
+		if c == nil {
+			firstFreeCell = i
+			break
+		}
+	}
+	firstEmptyColumn := -1
+	for i, column := range p.Tableau {
+		if len(column) == 0 {
+			firstEmptyColumn = i
+			break
+		}
+	}
+	emptyFree, emptyCols := p.empties()
+	maxRun := maxMovableCards(emptyFree, emptyCols)
+
+	for from, column := range p.Tableau {
+		if len(column) == 0 {
+			continue
+		}
+		top := column[len(column)-1]
+
+		if p.canMoveToFoundation(top) {
+			moves = append(moves, Move{From: TableauPile, FromIndex: from, To: FoundationPile, ToIndex: int(top.Suit), Count: 1})
+		}
+		if firstFreeCell >= 0 {
+			moves = append(moves, Move{From: TableauPile, FromIndex: from, To: FreeCellPile, ToIndex: firstFreeCell, Count: 1})
+		}
+
+		// Every run of alternating, descending cards ending at the top can move together
+		for start := len(column) - 1; start >= 0; start-- {
+			if start < len(column)-1 && !canMoveToTableau(column[start+1], column[start:start+1]) {
+				break
+			}
+			count := len(column) - start
+			if count > 1 && count > maxRun {
+				break
+			}
+			for to, target := range p.Tableau {
+				if to == from || (len(target) == 0 && to != firstEmptyColumn) {
+					continue
+				}
+				// Moving a whole column into an empty one changes nothing
+				if len(target) == 0 && start == 0 {
+					continue
+				}
+				if canMoveToTableau(column[start], target) {
+					moves = append(moves, Move{From: TableauPile, FromIndex: from, To: TableauPile, ToIndex: to, Count: count})
+				}
+			}
+		}
+	}
+
+	for from, c := range p.FreeCells {
+		if c == nil {
+			continue
+		}
+		if p.canMoveToFoundation(*c) {
+			moves = append(moves, Move{From: FreeCellPile, FromIndex: from, To: FoundationPile, ToIndex: int(c.Suit), Count: 1})
+		}
+		for to, target := range p.Tableau {
+			if len(target) == 0 && to != firstEmptyColumn {
+				continue
+			}
+			if canMoveToTableau(*c, target) {
+				moves = append(moves, Move{From: FreeCellPile, FromIndex: from, To: TableauPile, ToIndex: to, Count: 1})
+			}
+		}
+	}
+
+	return moves
+}
+
+// Apply returns the position after m, leaving p unchanged. m must come from LegalMoves.
+func (p Position) Apply(m Move) Position {
+	next := p
+	for i := range next.Tableau {
+		next.Tableau[i] = append([]Card(nil), p.Tableau[i]...)
+	}
+
+	var cards []Card
+	switch m.From {
+	case TableauPile:
+		column := next.Tableau[m.FromIndex]
+		cards = column[len(column)-m.Count:]
+		next.Tableau[m.FromIndex] = column[:len(column)-m.Count]
+	case FreeCellPile:
+		cards = []Card{*next.FreeCells[m.FromIndex]}
+		next.FreeCells[m.FromIndex] = nil
+	}
+
+	switch m.To {
+	case TableauPile:
+		next.Tableau[m.ToIndex] = append(next.Tableau[m.ToIndex], cards...)
+	case FreeCellPile:
+		c := cards[0]
+		next.FreeCells[m.ToIndex] = &c
+	case FoundationPile:
+		next.Foundations[cards[0].Suit] = cards[0].Rank
+	}
+	return next
+}
+
+// Safe rank offset for auto-play, matching the frontend's AUTO_MOVE_SAFE_RANK_OFFSET
+const autoMoveSafeRankOffset = 2
+
+// AutoPlay repeatedly moves cards to the foundations that can never be
+// needed in the tableau again, as the game does after every manual move.
+// It returns the new position and the moves made.
+func (p Position) AutoPlay() (Position, []Move) {
+	var played []Move
+	for {
+		move, ok := p.safeFoundationMove()
+		if !ok {
+			return p, played
+		}
+		p = p.Apply(move)
+		played = append(played, move)
+	}
+}
+
+// Helper function to find one safe foundation move, free cells first like the game
+func (p *Position) safeFoundationMove() (Move, bool) {
+	safe := func(c Card) bool {
+		if !p.canMoveToFoundation(c) {
+			return false
+		}
+		opposite := min(p.Foundations[Clubs], p.Foundations[Spades])
+		if !c.Suit.IsRed() {
+			opposite = min(p.Foundations[Diamonds], p.Foundations[Hearts])
+		}
+		return c.Rank <= opposite+autoMoveSafeRankOffset
+	}
+	for i, c := range p.FreeCells {
+		if c != nil && safe(*c) {
+			return Move{From: FreeCellPile, FromIndex: i, To: FoundationPile, ToIndex: int(c.Suit), Count: 1}, true
+		}
+	}
+	for i, column := range p.Tableau {
+		if len(column) > 0 && safe(column[len(column)-1]) {
+			c := column[len(column)-1]
+			return Move{From: TableauPile, FromIndex: i, To: FoundationPile, ToIndex: int(c.Suit), Count: 1}, true
+		}
+	}
+	return Move{}, false
+}
+
+// Key returns a string identifying the position, for repetition detection.
+// Free cells are sorted since their order doesn't matter.
+func (p Position) Key() string {
+	var b strings.Builder
+	for _, column := range p.Tableau {
+		for _, c := range column {
+			b.WriteString(c.Code())
+		}
+		b.WriteByte('|')
+	}
+	cells := make([]string, 0, freeCellCount)
+	for _, c := range p.FreeCells {
+		if c != nil {
+			cells = append(cells, c.Code())
+		}
+	}
+	slices.Sort(cells)
+	b.WriteString(strings.Join(cells, ""))
+	b.WriteByte('|')
+	for _, r := range p.Foundations {
+		if r == 0 {
+			b.WriteByte('-')
+		} else {
+			b.WriteString(rankLetters[r])
+		}
+	}
+	return b.String()
+}
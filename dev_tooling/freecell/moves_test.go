@@ -0,0 +1,187 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Helper function to parse a card code such as "TH" or "AS"
+func parseCode(t *testing.T, code string) Card {
+	t.Helper()
+	rank := slices.Index(rankLetters[:], code[:1])
+	suit := slices.Index(suitLetters[:], code[1:])
+	if rank < 1 || suit < 0 || len(code) != 2 {
+		t.Fatalf("bad card code %q", code)
+	}
+	return Card{Rank: Rank(rank), Suit: Suit(suit)}
+}
+
+// Helper function to build a position from space-separated columns (bottom
+// card first; "" for an empty column) and free cell codes ("" for empty)
+func buildPosition(t *testing.T, columns []string, freeCells ...string) Position {
+	t.Helper()
+	var p Position
+	for i, column := range columns {
+		for _, code := range strings.Fields(column) {
+			p.Tableau[i] = append(p.Tableau[i], parseCode(t, code))
+		}
+	}
+	for i, code := range freeCells {
+		if code != "" {
+			c := parseCode(t, code)
+			p.FreeCells[i] = &c
+		}
+	}
+	return p
+}
+
+func TestMaxMovableCards(t *testing.T) {
+	tests := []struct {
+		freeCells, columns, want int
+	}{
+		{0, 0, 1},
+		{3, 0, 4},
+		{4, 0, 5},
+		{0, 1, 1}, // one empty column is left out of the count
+		{1, 1, 2},
+		{0, 2, 2},
+		{1, 2, 4},
+		{4, 3, 20},
+	}
+	for _, tt := range tests {
+		if got := maxMovableCards(tt.freeCells, tt.columns); got != tt.want {
+			t.Errorf("maxMovableCards(%d, %d) = %d, want %d", tt.freeCells, tt.columns, got, tt.want)
+		}
+	}
+}
+
+func TestLegalMovesSequenceLimit(t *testing.T) {
+	// Column 1 ends in the run 9H 8S 7H 6S, which can go on TS in column 2
+	run := Move{From: TableauPile, FromIndex: 0, To: TableauPile, ToIndex: 1, Count: 4}
+
+	tests := []struct {
+		name      string
+		columns   []string
+		freeCells []string
+		want      bool
+	}{
+		{
+			name:      "no free cells or empty columns",
+			columns:   []string{"KC 9H 8S 7H 6S", "TS", "2C", "2D", "2H", "2S", "3C", "3D"},
+			freeCells: []string{"4C", "4D", "4H", "4S"},
+			want:      false,
+		},
+		{
+			name:      "three free cells",
+			columns:   []string{"KC 9H 8S 7H 6S", "TS", "2C", "2D", "2H", "2S", "3C", "3D"},
+			freeCells: []string{"4C", "", "", ""},
+			want:      true,
+		},
+		{
+			name:      "two free cells",
+			columns:   []string{"KC 9H 8S 7H 6S", "TS", "2C", "2D", "2H", "2S", "3C", "3D"},
+			freeCells: []string{"4C", "4D", "", ""},
+			want:      false,
+		},
+		{
+			name:      "one free cell and one empty column",
+			columns:   []string{"KC 9H 8S 7H 6S", "TS", "", "2D", "2H", "2S", "3C", "3D"},
+			freeCells: []string{"4C", "4D", "4H", ""},
+			want:      false,
+		},
+		{
+			name:      "one free cell and two empty columns",
+			columns:   []string{"KC 9H 8S 7H 6S", "TS", "", "", "2H", "2S", "3C", "3D"},
+			freeCells: []string{"4C", "4D", "4H", ""},
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		p := buildPosition(t, tt.columns, tt.freeCells...)
+		if got := slices.Contains(p.LegalMoves(), run); got != tt.want {
+			t.Errorf("%s: 4-card move allowed = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLegalMovesEmptyColumns(t *testing.T) {
+	// Column 1 is a run on its own; column 2 has a run on top of a king
+	p := buildPosition(t,
+		[]string{"9H 8S", "KC 9D 8C", "", "", "2H", "2S", "3C", "3D"},
+		"4C", "4D", "", "")
+	moves := p.LegalMoves()
+
+	tests := []struct {
+		name string
+		move Move
+		want bool
+	}{
+		{"whole column into an empty column", Move{From: TableauPile, FromIndex: 0, To: TableauPile, ToIndex: 2, Count: 2}, false},
+		{"top card of a whole-column run", Move{From: TableauPile, FromIndex: 0, To: TableauPile, ToIndex: 2, Count: 1}, true},
+		{"part of a column into an empty column", Move{From: TableauPile, FromIndex: 1, To: TableauPile, ToIndex: 2, Count: 2}, true},
+		{"second empty column", Move{From: TableauPile, FromIndex: 1, To: TableauPile, ToIndex: 3, Count: 2}, false},
+		{"second empty free cell", Move{From: TableauPile, FromIndex: 1, To: FreeCellPile, ToIndex: 3, Count: 1}, false},
+	}
+	for _, tt := range tests {
+		if got := slices.Contains(moves, tt.move); got != tt.want {
+			t.Errorf("%s: move %v listed = %v, want %v", tt.name, tt.move, got, tt.want)
+		}
+	}
+}
+
+func TestAutoPlaySafeThreshold(t *testing.T) {
+	tests := []struct {
+		name        string
+		card        string
+		inFreeCell  bool
+		foundations [4]Rank // clubs, diamonds, hearts, spades
+		want        bool
+	}{
+		{"ace", "AS", false, [4]Rank{0, 0, 0, 0}, true},
+		{"two on empty opposite foundations", "2S", false, [4]Rank{0, 0, 0, 1}, true},
+		{"red card at the threshold", "4H", false, [4]Rank{2, 3, 3, 2}, true},
+		{"red card above the threshold", "5H", false, [4]Rank{2, 4, 4, 5}, false},
+		{"black card at the threshold", "3C", true, [4]Rank{2, 1, 2, 0}, true},
+		{"black card above the threshold", "4C", true, [4]Rank{3, 1, 5, 3}, false},
+		{"not playable", "6D", false, [4]Rank{5, 4, 5, 5}, false},
+	}
+
+	for _, tt := range tests {
+		var p Position
+		if tt.inFreeCell {
+			p = buildPosition(t, nil, tt.card)
+		} else {
+			p = buildPosition(t, []string{tt.card})
+		}
+		p.Foundations = tt.foundations
+
+		_, played := p.AutoPlay()
+		if got := len(played) == 1; got != tt.want {
+			t.Errorf("%s: %s auto-played = %v, want %v", tt.name, tt.card, got, tt.want)
+		}
+	}
+}
+
+func TestApplyLeavesReceiverUnchanged(t *testing.T) {
+	positions := []Position{
+		NewPosition(Deal(1)),
+		buildPosition(t,
+			[]string{"9H 8S", "KC 9D 8C", "", "TS", "2H", "AS", "3C", "3D"},
+			"4C", "", "7H", ""),
+	}
+
+	for _, p := range positions {
+		before := p.Key()
+		for _, m := range p.LegalMoves() {
+			next := p.Apply(m)
+			if p.Key() != before {
+				t.Fatalf("Apply(%v) changed the receiver", m)
+			}
+			if next.Key() == before {
+				t.Errorf("Apply(%v) returned an unchanged position", m)
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
+// Policy picks the next move during a simulated game
+type Policy interface {
+	Name() string
+	// Choose returns the index into moves to play. moves is never empty.
+	Choose(p *Position, moves []Move, rng *rand.Rand) int
+}
+
+// Available policies, keyed by the -policy flag value
+var policies = map[string]func() Policy{
+	"random": func() Policy { return randomPolicy{} },
+	"greedy": func() Policy { return greedyPolicy{} },
+}
+
+// Helper function to list policy names for usage and error messages
+func policyNames() []string {
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Helper function to look up policies from a comma-separated list
+func parsePolicies(list string) ([]Policy, error) {
+	var out []Policy
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if name == "solver" {
+			return nil, fmt.Errorf("the solver-guided policy needs a solver, which this tool doesn't have yet")
+		}
+		newPolicy, ok := policies[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown policy %q (available: %s)", name, strings.Join(policyNames(), ", "))
+		}
+		out = append(out, newPolicy())
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no policies given")
+	}
+	return out, nil
+}
+
+// randomPolicy plays a uniformly random legal move
+type randomPolicy struct{}
+
+func (randomPolicy) Name() string { return "random" }
+
+func (randomPolicy) Choose(_ *Position, moves []Move, rng *rand.Rand) int {
+	return rng.IntN(len(moves))
+}
+
+// greedyPolicy scores each move with simple one-ply heuristics and plays
+// the best, breaking ties at random. It is the baseline for tuning hints.
+type greedyPolicy struct{}
+
+func (greedyPolicy) Name() string { return "greedy" }
+
+func (greedyPolicy) Choose(p *Position, moves []Move, rng *rand.Rand) int {
+	best, bestScore := 0, -1<<31
+	for i, m := range moves {
+		score := greedyScore(p, m)*16 + rng.IntN(16)
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
+
+// Helper function to score one move for the greedy policy
+func greedyScore(p *Position, m Move) int {
+	score := 0
+	switch {
+	case m.To == FoundationPile:
+		score += 100
+	case m.From == FreeCellPile && m.To == TableauPile:
+		// Getting cards out of free cells restores mobility
+		score += 40
+		if len(p.Tableau[m.ToIndex]) == 0 {
+			score -= 30
+		}
+	case m.From == TableauPile && m.To == TableauPile:
+		score += 20 + m.Count
+		if len(p.Tableau[m.ToIndex]) == 0 {
+			score -= 25
+		}
+	case m.To == FreeCellPile:
+		score -= 10
+	}
+
+	// Emptying a column is worth a lot; exposing a low card is good too
+	if m.From == TableauPile {
+		column := p.Tableau[m.FromIndex]
+		remaining := len(column) - m.Count
+		if remaining == 0 {
+			score += 30
+		} else {
+			exposed := column[remaining-1]
+			score += int(14-exposed.Rank) / 2
+			// Breaking up a run that was already built in sequence goes nowhere
+			if m.To != FoundationPile && canMoveToTableau(column[remaining], column[remaining-1:remaining]) {
+				score -= 40
+			}
+		}
+	}
+	return score
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Position is a complete board: tableau, free cells and foundations
+type Position struct {
+	Tableau     [tableauColumns][]Card
+	FreeCells   [freeCellCount]*Card
+	Foundations [4]Rank // top rank on each foundation, indexed by Suit; 0 when empty
+}
+
+// NewPosition returns the starting position for a dealt tableau
+func NewPosition(tableau [tableauColumns][]Card) Position {
+	return Position{Tableau: tableau}
+}
+
+// jsonCard matches the frontend's Card type. Symbol, ColorClass and Label
+// are derived from the suit and rank, so they are ignored when reading.
+type jsonCard struct {
+	Suit       string `json:"suit"`
+	Rank       string `json:"rank"`
+	ID         string `json:"id"`
+	Symbol     string `json:"symbol"`
+	ColorClass string `json:"colorClass"`
+	Label      string `json:"label"`
+}
+
+// jsonGameState matches the frontend's GameState shape
+type jsonGameState struct {
+	GameNumber  uint32                `json:"gameNumber"`
+	Tableau     [][]jsonCard          `json:"tableau"`
+	FreeCells   []*jsonCard           `json:"freeCells"`
+	Foundations map[string][]jsonCard `json:"foundations"`
+	MoveHistory []any                 `json:"moveHistory"`
+	IsWon       bool                  `json:"isWon"`
+}
+
+// Helper function to convert a card to its frontend JSON form
+func toJSONCard(c Card) jsonCard {
+	return jsonCard{
+		Suit:       c.Suit.String(),
+		Rank:       c.Rank.String(),
+		ID:         c.ID(),
+		Symbol:     c.Suit.Symbol(),
+		ColorClass: c.Suit.ColorClass(),
+		Label:      c.Name(),
+	}
+}
+
+// Helper function to convert a frontend JSON card back to a Card
+func fromJSONCard(jc jsonCard) (Card, error) {
+	var c Card
+	for s, name := range suitNames {
+		if name == jc.Suit {
+			c.Suit = Suit(s)
+		}
+	}
+	for r, name := range rankNames {
+		if r > 0 && name == jc.Rank {
+			c.Rank = Rank(r)
+		}
+	}
+	if c.Rank == 0 || suitNames[c.Suit] != jc.Suit {
+		return Card{}, fmt.Errorf("unknown card %q of %q", jc.Rank, jc.Suit)
+	}
+	return c, nil
+}
+
+// toGameState converts a position to the frontend's GameState shape
+func (p Position) toGameState(gameNumber uint32) jsonGameState {
+	state := jsonGameState{
+		GameNumber:  gameNumber,
+		Tableau:     make([][]jsonCard, len(p.Tableau)),
+		FreeCells:   make([]*jsonCard, len(p.FreeCells)),
+		Foundations: map[string][]jsonCard{},
+		MoveHistory: []any{},
+	}
+	for col, column := range p.Tableau {
+		state.Tableau[col] = make([]jsonCard, len(column))
+		for i, card := range column {
+			state.Tableau[col][i] = toJSONCard(card)
+		}
+	}
+	for i, card := range p.FreeCells {
+		if card != nil {
+			jc := toJSONCard(*card)
+			state.FreeCells[i] = &jc
+		}
+	}
+	for s, name := range suitNames {
+		pile := []jsonCard{}
+		for r := Rank(1); r <= p.Foundations[s]; r++ {
+			pile = append(pile, toJSONCard(Card{Rank: r, Suit: Suit(s)}))
+		}
+		state.Foundations[name] = pile
+	}
+	state.IsWon = p.Foundations == [4]Rank{13, 13, 13, 13}
+	return state
+}
+
+// readPosition parses a position from the frontend's GameState JSON
+func readPosition(r io.Reader) (Position, uint32, error) {
+	var state jsonGameState
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return Position{}, 0, fmt.Errorf("reading position: %w", err)
+	}
+
+	var p Position
+	if len(state.Tableau) != tableauColumns {
+		return Position{}, 0, fmt.Errorf("tableau has %d columns, want %d", len(state.Tableau), tableauColumns)
+	}
+	if len(state.FreeCells) > freeCellCount {
+		return Position{}, 0, fmt.Errorf("%d free cells, want at most %d", len(state.FreeCells), freeCellCount)
+	}
+
+	for col, column := range state.Tableau {
+		for _, jc := range column {
+			card, err := fromJSONCard(jc)
+			if err != nil {
+				return Position{}, 0, fmt.Errorf("column %d: %w", col+1, err)
+			}
+			p.Tableau[col] = append(p.Tableau[col], card)
+		}
+	}
+	for i, jc := range state.FreeCells {
+		if jc == nil {
+			continue
+		}
+		card, err := fromJSONCard(*jc)
+		if err != nil {
+			return Position{}, 0, fmt.Errorf("free cell %d: %w", i+1, err)
+		}
+		p.FreeCells[i] = &card
+	}
+	for s, name := range suitNames {
+		for i, jc := range state.Foundations[name] {
+			card, err := fromJSONCard(jc)
+			if err != nil {
+				return Position{}, 0, fmt.Errorf("%s foundation: %w", name, err)
+			}
+			// Foundations must run ace upwards in their own suit
+			if card.Suit != Suit(s) || card.Rank != Rank(i+1) {
+				return Position{}, 0, fmt.Errorf("%s foundation: %s out of sequence", name, card.Label())
+			}
+		}
+		p.Foundations[s] = Rank(len(state.Foundations[name]))
+	}
+	return p, state.GameNumber, nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Layouts for text rendering
+var renderLayouts = []string{"compact", "spread"}
+
+// RenderOptions controls how a position is drawn as text
+type RenderOptions struct {
+	Layout string // "compact" or "spread"
+	ASCII  bool   // letters (TH) instead of Unicode suit symbols (10♥)
+	Color  bool   // ANSI red for hearts and diamonds
+}
+
+// ANSI escape codes for colored output
+const (
+	ansiRed   = "\x1b[31m"
+	ansiReset = "\x1b[0m"
+)
+
+// Helper function to get a card's text, plain and as displayed
+func (o RenderOptions) card(c Card) (plain, shown string) {
+	plain = c.Label()
+	if o.ASCII {
+		plain = c.Code()
+	}
+	shown = plain
+	if o.Color && c.Suit.IsRed() {
+		shown = ansiRed + plain + ansiReset
+	}
+	return plain, shown
+}
+
+// Helper function to show an empty foundation as its suit
+func (o RenderOptions) suit(s Suit) (plain, shown string) {
+	plain = suitSymbols[s]
+	if o.ASCII {
+		plain = suitLetters[s]
+	}
+	shown = plain
+	if o.Color && s.IsRed() {
+		shown = ansiRed + plain + ansiReset
+	}
+	return plain, shown
+}
+
+// Helper function to right-align a cell, padding by visible width so
+// suit symbols and color codes don't throw columns out
+func (o RenderOptions) cell(plain, shown string) string {
+	pad := strings.Repeat(" ", max(0, 3-len([]rune(plain))))
+	if o.Layout == "spread" {
+		return "[" + pad + shown + "]"
+	}
+	return pad + shown
+}
+
+// Helper function to join cells in a row
+func (o RenderOptions) row(cells []string) string {
+	sep := " "
+	if o.Layout == "spread" {
+		sep = "  "
+	}
+	return strings.TrimRight(strings.Join(cells, sep), " ")
+}
+
+// renderPosition writes a fixed-width diagram of p, suitable for pasting
+// into forums and issues. The top row shows free cells then foundations,
+// lined up with the eight tableau columns below.
+func renderPosition(w io.Writer, title string, p Position, o RenderOptions) error {
+	var b strings.Builder
+	if title != "" {
+		fmt.Fprintf(&b, "%s\n\n", title)
+	}
+
+	top := make([]string, 0, freeCellCount+len(p.Foundations))
+	for _, c := range p.FreeCells {
+		if c == nil {
+			top = append(top, o.cell("--", "--"))
+		} else {
+			top = append(top, o.cell(o.card(*c)))
+		}
+	}
+	for s, rank := range p.Foundations {
+		if rank == 0 {
+			top = append(top, o.cell(o.suit(Suit(s))))
+		} else {
+			top = append(top, o.cell(o.card(Card{Rank: rank, Suit: Suit(s)})))
+		}
+	}
+
+	if o.Layout == "spread" {
+		b.WriteString(" Free cells" + strings.Repeat(" ", 17) + "Foundations\n")
+	}
+	b.WriteString(o.row(top) + "\n")
+	if o.Layout == "spread" {
+		b.WriteString(strings.Repeat("=", tableauColumns*7-2) + "\n")
+		nums := make([]string, tableauColumns)
+		for col := range nums {
+			nums[col] = fmt.Sprintf("  %d  ", col+1)
+		}
+		b.WriteString(o.row(nums) + "\n")
+	} else {
+		b.WriteString("\n")
+	}
+
+	depth := 0
+	for _, column := range p.Tableau {
+		depth = max(depth, len(column))
+	}
+	for r := 0; r < depth; r++ {
+		cells := make([]string, len(p.Tableau))
+		for col, column := range p.Tableau {
+			if r < len(column) {
+				cells[col] = o.cell(o.card(column[r]))
+			} else if o.Layout == "spread" {
+				cells[col] = "     "
+			} else {
+				cells[col] = "   "
+			}
+		}
+		b.WriteString(o.row(cells) + "\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
+// Why a simulated game ended
+const (
+	endWon     = "won"
+	endStuck   = "stuck"    // no legal moves, or every move repeats a position
+	endMoveCap = "move_cap" // hit -max-moves
+)
+
+// GameResult is the outcome of one simulated game
+type GameResult struct {
+	Deal  uint32 `json:"deal"`
+	End   string `json:"end"`
+	Moves int    `json:"moves"`
+}
+
+// PolicyReport aggregates results for one policy
+type PolicyReport struct {
+	Policy        string         `json:"policy"`
+	Games         int            `json:"games"`
+	Wins          int            `json:"wins"`
+	WinRate       float64        `json:"win_rate"`
+	AvgMovesToWin float64        `json:"avg_moves_to_win"`
+	Ends          map[string]int `json:"ends"`
+	MoveKinds     map[string]int `json:"move_kinds"`
+	Results       []GameResult   `json:"results,omitempty"`
+}
+
+// SimulateOptions controls a simulation run
+type SimulateOptions struct {
+	FirstDeal uint32
+	Deals     int
+	MaxMoves  int
+	Seed      uint64
+	AutoPlay  bool
+	KeepGames bool
+	Check     bool // verify invariants after every move, panicking on a violation
+}
+
+// playGame plays one deal to the end with policy, counting move kinds into kinds
+func playGame(dealNum uint32, policy Policy, opts SimulateOptions, kinds map[string]int) GameResult {
+	rng := rand.New(rand.NewPCG(opts.Seed, uint64(dealNum)))
+	pos := NewPosition(Deal(dealNum))
+	apply := Position.Apply
+	if opts.Check {
+		apply = Position.ApplyChecked
+		if err := pos.Check(); err != nil {
+			panic(fmt.Sprintf("freecell: deal %d breaks an invariant: %v", dealNum, err))
+		}
+		// Say which game broke, so it can be replayed with -start and -seed
+		defer func() {
+			if r := recover(); r != nil {
+				panic(fmt.Sprintf("deal %d, policy %s, seed %d: %v", dealNum, policy.Name(), opts.Seed, r))
+			}
+		}()
+	}
+	seen := map[string]bool{pos.Key(): true}
+
+	count := func(moves ...Move) {
+		for _, m := range moves {
+			kinds[m.Kind()]++
+		}
+	}
+
+	played := 0
+	for played < opts.MaxMoves {
+		if pos.IsWon() {
+			return GameResult{Deal: dealNum, End: endWon, Moves: played}
+		}
+
+		// Only consider moves that reach a position we haven't seen, so games can't cycle
+		var moves []Move
+		for _, m := range pos.LegalMoves() {
+			if !seen[pos.Apply(m).Key()] {
+				moves = append(moves, m)
+			}
+		}
+		if len(moves) == 0 {
+			return GameResult{Deal: dealNum, End: endStuck, Moves: played}
+		}
+
+		m := moves[policy.Choose(&pos, moves, rng)]
+		pos = apply(pos, m)
+		count(m)
+		played++
+
+		if opts.AutoPlay {
+			next, auto := pos.AutoPlay()
+			if opts.Check {
+				next = applyAllChecked(pos, auto)
+			}
+			pos = next
+			count(auto...)
+			played += len(auto)
+		}
+		seen[pos.Key()] = true
+	}
+
+	if pos.IsWon() {
+		return GameResult{Deal: dealNum, End: endWon, Moves: played}
+	}
+	return GameResult{Deal: dealNum, End: endMoveCap, Moves: played}
+}
+
+// simulate plays every deal in the range with each policy
+func simulate(pols []Policy, opts SimulateOptions) []PolicyReport {
+	reports := make([]PolicyReport, 0, len(pols))
+	for _, policy := range pols {
+		r := PolicyReport{
+			Policy:    policy.Name(),
+			Ends:      map[string]int{endWon: 0, endStuck: 0, endMoveCap: 0},
+			MoveKinds: map[string]int{},
+		}
+		winMoves := 0
+		for i := 0; i < opts.Deals; i++ {
+			res := playGame(opts.FirstDeal+uint32(i), policy, opts, r.MoveKinds)
+			r.Games++
+			r.Ends[res.End]++
+			if res.End == endWon {
+				r.Wins++
+				winMoves += res.Moves
+			}
+			if opts.KeepGames {
+				r.Results = append(r.Results, res)
+			}
+		}
+		if r.Games > 0 {
+			r.WinRate = float64(r.Wins) / float64(r.Games)
+		}
+		if r.Wins > 0 {
+			r.AvgMovesToWin = float64(winMoves) / float64(r.Wins)
+		}
+		reports = append(reports, r)
+	}
+	return reports
+}
+
+// Helper function to print reports as a table
+func writeSimulationText(w io.Writer, opts SimulateOptions, reports []PolicyReport) error {
+	var b strings.Builder
+	last := opts.FirstDeal + uint32(opts.Deals) - 1
+	fmt.Fprintf(&b, "Deals %d-%d, max %d moves, seed %d\n\n", opts.FirstDeal, last, opts.MaxMoves, opts.Seed)
+	fmt.Fprintf(&b, "%-8s %6s %6s %8s %18s %6s %9s\n", "Policy", "Games", "Wins", "Win %", "Avg moves (wins)", "Stuck", "Move cap")
+	for _, r := range reports {
+		fmt.Fprintf(&b, "%-8s %6d %6d %7.1f%% %18.1f %6d %9d\n",
+			r.Policy, r.Games, r.Wins, r.WinRate*100, r.AvgMovesToWin, r.Ends[endStuck], r.Ends[endMoveCap])
+	}
+
+	for _, r := range reports {
+		total := 0
+		kinds := make([]string, 0, len(r.MoveKinds))
+		for kind, n := range r.MoveKinds {
+			kinds = append(kinds, kind)
+			total += n
+		}
+		slices.Sort(kinds)
+		fmt.Fprintf(&b, "\n%s move distribution (%d moves)\n", r.Policy, total)
+		for _, kind := range kinds {
+			n := r.MoveKinds[kind]
+			fmt.Fprintf(&b, "  %-22s %8d %6.1f%%\n", kind, n, float64(n)*100/float64(max(total, 1)))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Helper function to print reports as JSON
+func writeSimulationJSON(w io.Writer, reports []PolicyReport) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(reports)
+}
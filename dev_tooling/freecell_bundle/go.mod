@@ -0,0 +1,8 @@
+module github.com/joshuamkite/freecell/freecell_bundle
+
+go 1.25.5
+
+require github.com/joshuamkite/freecell/precache_manifest v0.0.0
+
+// Shares the precache manifest builder with dev_tooling/precache_manifest
+replace github.com/joshuamkite/freecell/precache_manifest => ../precache_manifest
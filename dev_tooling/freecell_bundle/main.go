@@ -0,0 +1,375 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Card pack manifest written by dev_tooling/download_cards
+type DeckManifest struct {
+	Deck        string `json:"deck"`
+	Description string `json:"description"`
+	Author      string `json:"author"`
+	License     string `json:"license"`
+	SourceURL   string `json:"source_url"`
+}
+
+// Attribution used when the card directory has no manifest
+var defaultAttribution = DeckManifest{
+	Deck:        "english",
+	Description: "SVG English pattern playing cards",
+	Author:      "Byron Knoll",
+	License:     "Public Domain",
+	SourceURL:   "https://commons.wikimedia.org/wiki/Category:SVG_English_pattern_playing_cards",
+}
+
+// Files that must keep a stable name: entry points, and the manifests describing the bundle
+var stableNames = map[string]bool{
+	"index.html":          true,
+	"asset-manifest.json": true,
+	"ATTRIBUTION.txt":     true,
+	"LICENSE":             true,
+	"favicon.png":         true,
+	"sw.js":               true,
+}
+
+// Text files whose literal references to renamed files are rewritten
+var rewriteExts = map[string]bool{".html": true, ".css": true, ".js": true, ".json": true, ".webmanifest": true}
+
+// Helper function to copy a single file, creating parent directories
+func copyFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// Helper function to copy a directory tree into dst
+func copyTree(src, dst string, filter func(rel string) bool) (int, error) {
+	count := 0
+	err := filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		if filter != nil && !filter(filepath.ToSlash(rel)) {
+			return nil
+		}
+		count++
+		return copyFile(p, filepath.Join(dst, rel))
+	})
+	return count, err
+}
+
+// Helper function to tell whether Vite (or we) already put a hash in the name
+func alreadyHashed(rel string) bool {
+	return strings.HasPrefix(rel, "assets/")
+}
+
+// Helper function to build "name.<hash>.ext" from "name.ext"
+func hashedName(rel, sum string) string {
+	ext := path.Ext(rel)
+	return strings.TrimSuffix(rel, ext) + "." + sum[:8] + ext
+}
+
+// hashAssets adds a content-hashed copy of every file that doesn't already
+// have one, rewrites literal references in text files, and returns the
+// original-to-hashed mapping. Originals are kept, because the game builds
+// some paths (such as card images) at runtime and they can't be rewritten.
+//
+// Files are processed in dependency order: a text file's references are
+// rewritten before it is hashed, so its hash (and hashed copy) reflects the
+// final content. References inside a cycle keep their original name, and so
+// do references inside Vite's already-hashed assets/.
+func hashAssets(dir string) (map[string]string, error) {
+	var files []string
+	texts := map[string]string{}
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		files = append(files, rel)
+		// Already-hashed files are never rewritten, since that would change
+		// their contents without changing their name. Their references to
+		// originals still work, because the originals are kept.
+		if rewriteExts[path.Ext(rel)] && !alreadyHashed(rel) {
+			data, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			texts[rel] = string(data)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	// Files that get a hashed copy, longest names first so "a/b.svg" is
+	// replaced before "b.svg"
+	var hashable []string
+	for _, rel := range files {
+		if !stableNames[rel] && !alreadyHashed(rel) {
+			hashable = append(hashable, rel)
+		}
+	}
+	sort.SliceStable(hashable, func(i, j int) bool { return len(hashable[i]) > len(hashable[j]) })
+
+	mapping := map[string]string{}
+	state := map[string]int{} // 0 unvisited, 1 in progress, 2 done
+
+	var visit func(rel string) error
+	visit = func(rel string) error {
+		state[rel] = 1
+		text, isText := texts[rel]
+		if isText {
+			for _, dep := range hashable {
+				if dep != rel && state[dep] == 0 && strings.Contains(text, "/"+dep) {
+					if err := visit(dep); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		state[rel] = 2
+
+		p := filepath.Join(dir, rel)
+		var data []byte
+		if isText {
+			for _, dep := range hashable {
+				if hashed, ok := mapping[dep]; ok {
+					text = strings.ReplaceAll(text, "/"+dep, "/"+hashed)
+				}
+			}
+			data = []byte(text)
+			if text != texts[rel] {
+				if err := os.WriteFile(p, data, 0o644); err != nil {
+					return err
+				}
+			}
+		}
+
+		if stableNames[rel] || alreadyHashed(rel) {
+			return nil
+		}
+		if data == nil {
+			if data, err = os.ReadFile(p); err != nil {
+				return err
+			}
+		}
+		sum := sha256.Sum256(data)
+		mapping[rel] = hashedName(rel, hex.EncodeToString(sum[:]))
+		return os.WriteFile(filepath.Join(dir, mapping[rel]), data, 0o644)
+	}
+
+	for _, rel := range files {
+		if state[rel] == 0 {
+			if err := visit(rel); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return mapping, nil
+}
+
+// Helper function to write ATTRIBUTION.txt from the card pack manifest
+func writeAttribution(dir, cardsDir string) error {
+	deck := defaultAttribution
+	if data, err := os.ReadFile(filepath.Join(cardsDir, "manifest.json")); err == nil {
+		if err := json.Unmarshal(data, &deck); err != nil {
+			return fmt.Errorf("reading card manifest: %w", err)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "FreeCell Solitaire\n")
+	fmt.Fprintf(&b, "https://github.com/joshuamkite/freecell\n")
+	fmt.Fprintf(&b, "Game code: GNU Affero General Public License v3 (see LICENSE)\n\n")
+	fmt.Fprintf(&b, "Card faces: %s\n", deck.Description)
+	fmt.Fprintf(&b, "  Author:  %s\n", deck.Author)
+	fmt.Fprintf(&b, "  License: %s\n", deck.License)
+	fmt.Fprintf(&b, "  Source:  %s\n", deck.SourceURL)
+	return os.WriteFile(filepath.Join(dir, "ATTRIBUTION.txt"), []byte(b.String()), 0o644)
+}
+
+// Helper function to write a JSON file
+func writeJSON(p string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, append(data, '\n'), 0o644)
+}
+
+// Helper function to check that a directory exists
+func requireDir(p, flagName string) error {
+	info, err := os.Stat(p)
+	if err != nil {
+		return fmt.Errorf("-%s: %w", flagName, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("-%s: %s is not a directory", flagName, p)
+	}
+	return nil
+}
+
+func main() {
+	distDir := flag.String("dist", "../../frontend/dist", "built frontend directory")
+	cardsDir := flag.String("cards", "", "card asset directory to include as cards/ (e.g. download_cards output); default uses the cards already in -dist")
+	wasmPath := flag.String("wasm", "", "optional WASM engine to include as engine.wasm")
+	licensePath := flag.String("license", "../../LICENSE", "license file to include")
+	outDir := flag.String("out", "../../bundle", "directory to assemble the bundle in")
+	archive := flag.String("archive", "", "also write the bundle as an archive (.zip, .tar.gz or .tgz)")
+	hash := flag.Bool("hash", true, "add content-hashed copies of files for cache-busting")
+	optimize := flag.Bool("optimize", true, "strip editor data and whitespace from card SVGs")
+	clean := flag.Bool("clean", false, "remove -out first if it already exists")
+	flag.Parse()
+
+	if err := run(*distDir, *cardsDir, *wasmPath, *licensePath, *outDir, *archive, *hash, *optimize, *clean); err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+}
+
+// run assembles the bundle
+func run(distDir, cardsDir, wasmPath, licensePath, outDir, archive string, hash, optimize, clean bool) error {
+	if err := requireDir(distDir, "dist"); err != nil {
+		return err
+	}
+	if cardsDir != "" {
+		if err := requireDir(cardsDir, "cards"); err != nil {
+			return err
+		}
+	}
+	if archive != "" && archiveFormat(archive) == "" {
+		return fmt.Errorf("unsupported archive %q: use .zip, .tar.gz or .tgz", archive)
+	}
+
+	// Never merge into an old bundle
+	if entries, err := os.ReadDir(outDir); err == nil && len(entries) > 0 {
+		if !clean {
+			return fmt.Errorf("%s is not empty (use -clean to replace it)", outDir)
+		}
+		if err := os.RemoveAll(outDir); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(outDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	fmt.Println("=== Assembling Bundle ===")
+
+	// Built frontend; leave its cards out if we are supplying our own. Any
+	// precache manifest is left out too, since it is regenerated for the bundle.
+	n, err := copyTree(distDir, outDir, func(rel string) bool {
+		if strings.HasPrefix(path.Base(rel), "precache-manifest.") {
+			return false
+		}
+		return cardsDir == "" || !strings.HasPrefix(rel, "cards/")
+	})
+	if err != nil {
+		return fmt.Errorf("copying frontend: %w", err)
+	}
+	fmt.Printf("  ✓ Frontend: %d files\n", n)
+
+	attributionDir := filepath.Join(distDir, "cards")
+	if cardsDir != "" {
+		n, err := copyTree(cardsDir, filepath.Join(outDir, "cards"), func(rel string) bool {
+			return path.Ext(rel) == ".svg"
+		})
+		if err != nil {
+			return fmt.Errorf("copying cards: %w", err)
+		}
+		fmt.Printf("  ✓ Cards: %d files\n", n)
+		attributionDir = cardsDir
+	}
+
+	if wasmPath != "" {
+		if err := copyFile(wasmPath, filepath.Join(outDir, "engine.wasm")); err != nil {
+			return fmt.Errorf("copying WASM engine: %w", err)
+		}
+		fmt.Println("  ✓ WASM engine")
+	}
+
+	if err := writeAttribution(outDir, attributionDir); err != nil {
+		return err
+	}
+	if licensePath != "" {
+		if err := copyFile(licensePath, filepath.Join(outDir, "LICENSE")); err != nil {
+			return fmt.Errorf("copying license: %w", err)
+		}
+	}
+	fmt.Println("  ✓ Attribution and license")
+
+	// Before hashing, so hashes cover the optimized cards
+	if optimize {
+		n, saved, err := optimizeCards(filepath.Join(outDir, "cards"))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("optimizing cards: %w", err)
+		}
+		fmt.Printf("  ✓ Optimized %d card files (%d KB saved)\n", n, saved/1024)
+	}
+
+	if hash {
+		mapping, err := hashAssets(outDir)
+		if err != nil {
+			return fmt.Errorf("hashing assets: %w", err)
+		}
+		if err := writeJSON(filepath.Join(outDir, "asset-manifest.json"), mapping); err != nil {
+			return err
+		}
+		fmt.Printf("  ✓ Hashed %d files (see asset-manifest.json)\n", len(mapping))
+	}
+
+	// Last, so it covers every file the bundle ends up with
+	if n, err := writePrecacheManifest(outDir); err != nil {
+		return fmt.Errorf("writing precache manifest: %w", err)
+	} else if n > 0 {
+		fmt.Printf("  ✓ Precache manifest: %d files\n", n)
+	}
+
+	if archive != "" {
+		if err := writeArchive(outDir, archive); err != nil {
+			return fmt.Errorf("writing archive: %w", err)
+		}
+		fmt.Printf("  ✓ Archive: %s\n", archive)
+	}
+
+	fmt.Printf("\nBundle ready: %s\n", outDir)
+	return nil
+}
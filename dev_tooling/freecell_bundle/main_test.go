@@ -0,0 +1,125 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Helper function to write a set of files under dir
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		p := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(p), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Helper function to read a file under dir as a string
+func readFile(t *testing.T, dir, rel string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, rel))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// Helper function to hash a string as hex SHA-256
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestHashAssets(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"index.html":             `<link href="/css/theme.css"><script src="/assets/index-abc123.js"></script>`,
+		"css/theme.css":          `x{background:url(/cards/a.svg)}`,
+		"cards/a.svg":            `<svg/>`,
+		"assets/index-abc123.js": `fetch("/cards/a.svg")`,
+		"app.js":                 `load("/js/b.js")`,
+		"js/b.js":                `load("/app.js")`,
+	})
+
+	mapping, err := hashAssets(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, rel := range []string{"index.html", "assets/index-abc123.js"} {
+		if _, ok := mapping[rel]; ok {
+			t.Errorf("%s got a hashed copy", rel)
+		}
+	}
+
+	// Hashed copies point at other hashed copies
+	css := readFile(t, dir, mapping["css/theme.css"])
+	if !strings.Contains(css, "/"+mapping["cards/a.svg"]) {
+		t.Errorf("hashed stylesheet = %q, want a reference to %s", css, mapping["cards/a.svg"])
+	}
+	if got := readFile(t, dir, "index.html"); !strings.Contains(got, "/"+mapping["css/theme.css"]) {
+		t.Errorf("index.html = %q, want a reference to %s", got, mapping["css/theme.css"])
+	}
+
+	// Vite's hashed assets keep their contents, so their names stay truthful
+	if got := readFile(t, dir, "assets/index-abc123.js"); got != `fetch("/cards/a.svg")` {
+		t.Errorf("assets/index-abc123.js was rewritten to %q", got)
+	}
+
+	// A hashed name matches the content of the hashed copy
+	for rel, hashed := range mapping {
+		data := readFile(t, dir, hashed)
+		if want := hashedName(rel, sha256Hex(data)); want != hashed {
+			t.Errorf("%s hashed as %s, but its content hashes to %s", rel, hashed, want)
+		}
+	}
+}
+
+func TestOptimizeSVG(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "strips editor data",
+			in: `<?xml version="1.0"?>
+<!-- Created with Inkscape -->
+<svg
+   xmlns="http://www.w3.org/2000/svg"
+   xmlns:inkscape="http://www.inkscape.org/namespaces/inkscape" width="10" height="10">
+  <metadata id="m"><rdf:RDF xmlns:rdf="x"/></metadata>
+  <sodipodi:namedview id="n" xmlns:sodipodi="y"><inkscape:grid/></sodipodi:namedview>
+  <path
+     d="M 0,0
+        L 10,10"
+     inkscape:connector-curvature="0" />
+</svg>`,
+			want: `<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg" xmlns:inkscape="http://www.inkscape.org/namespaces/inkscape" width="10" height="10"><path d="M 0,0 L 10,10" /></svg>` + "\n",
+		},
+		{
+			name: "keeps the original if the result is malformed",
+			in:   "<svg>\n<!-- x -->\n<g></svg>",
+			want: "<svg>\n<!-- x -->\n<g></svg>",
+		},
+		{
+			name: "leaves text alone",
+			in:   "<svg>\n  <text>A  B</text>\n</svg>",
+			want: "<svg>\n  <text>A  B</text>\n</svg>",
+		},
+	}
+	for _, tt := range tests {
+		if got := string(optimizeSVG([]byte(tt.in))); got != tt.want {
+			t.Errorf("%s:\n got %q\nwant %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Editor data that doesn't affect how a card is drawn
+var (
+	svgComment  = regexp.MustCompile(`(?s)<!--.*?-->`)
+	svgMetadata = regexp.MustCompile(`(?s)<metadata\b.*?</metadata>`)
+	// Inkscape and Sodipodi elements, self-closing or with content
+	svgEditorElement = regexp.MustCompile(`(?s)<(inkscape|sodipodi):[\w-]+\b[^>]*/>|<(inkscape|sodipodi):([\w-]+)\b[^>]*>.*?</(inkscape|sodipodi):[\w-]+>`)
+	svgEditorAttr    = regexp.MustCompile(`\s+(inkscape|sodipodi):[\w-]+="[^"]*"`)
+	svgTag           = regexp.MustCompile(`<[^>]+>`)
+	svgSpace         = regexp.MustCompile(`\s+`)
+	svgBetweenTags   = regexp.MustCompile(`>\s+<`)
+)
+
+// Helper function to check a document is still well-formed XML
+func wellFormed(data []byte) bool {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		_, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			return true
+		}
+		if err != nil {
+			return false
+		}
+	}
+}
+
+// optimizeSVG strips editor data (comments, metadata, Inkscape and Sodipodi
+// elements and attributes) and collapses whitespace. It is deliberately
+// conservative: files with text are left alone, since whitespace matters there,
+// and the original is returned if the result isn't well-formed.
+func optimizeSVG(data []byte) []byte {
+	src := string(data)
+	if strings.Contains(src, "<text") || strings.Contains(src, "xml:space") {
+		return data
+	}
+
+	out := svgComment.ReplaceAllString(src, "")
+	out = svgMetadata.ReplaceAllString(out, "")
+	out = svgEditorElement.ReplaceAllString(out, "")
+	out = svgEditorAttr.ReplaceAllString(out, "")
+	out = svgTag.ReplaceAllStringFunc(out, func(tag string) string {
+		return svgSpace.ReplaceAllString(tag, " ")
+	})
+	out = svgBetweenTags.ReplaceAllString(out, "><")
+	out = strings.TrimSpace(out) + "\n"
+
+	if !wellFormed([]byte(out)) {
+		return data
+	}
+	return []byte(out)
+}
+
+// optimizeCards optimizes every SVG under dir in place and returns the
+// number of files and bytes saved
+func optimizeCards(dir string) (files int, saved int64, err error) {
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(p) != ".svg" {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		out := optimizeSVG(data)
+		if len(out) >= len(data) {
+			return nil
+		}
+		files++
+		saved += int64(len(data) - len(out))
+		return os.WriteFile(p, out, 0o644)
+	})
+	return files, saved, err
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/joshuamkite/freecell/precache_manifest/precache"
+)
+
+// Name of the precache manifest the service worker loads with importScripts
+// (see dev_tooling/precache_manifest -format=js)
+const precacheManifestName = "precache-manifest.js"
+
+// writePrecacheManifest regenerates the precache manifest for the finished
+// bundle. Files with a hashed copy are only precached once, under the hashed
+// name, with their original name as an alias. It is only written when the
+// bundle has the service worker, and returns the file count.
+func writePrecacheManifest(dir string) (int, error) {
+	if _, err := os.Stat(filepath.Join(dir, "sw.js")); err != nil {
+		return 0, nil
+	}
+
+	m, err := precache.Build(dir, "/", precache.DefaultExclude)
+	if err != nil {
+		return 0, err
+	}
+	data, err := precache.Encode(m, "js")
+	if err != nil {
+		return 0, err
+	}
+	return len(m.Files), os.WriteFile(filepath.Join(dir, precacheManifestName), data, 0o644)
+}
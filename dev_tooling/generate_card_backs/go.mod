@@ -0,0 +1,3 @@
+module github.com/joshuamkite/freecell/generate_card_backs
+
+go 1.25.5
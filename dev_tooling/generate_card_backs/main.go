@@ -0,0 +1,207 @@
+package main
+
+import (
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Card dimensions: 5:7, the ratio the game draws cards at, and the size
+// download_cards normalizes the card faces to, so backs and faces line up
+const (
+	cardWidth  = 250
+	cardHeight = 350
+	cornerR    = 12
+	borderW    = 10
+)
+
+// Options controls how a card back is drawn
+type Options struct {
+	Motif    string // one of the keys in motifs
+	Color    string // main color, hex
+	Accent   string // pattern/detail color, hex
+	Monogram string // up to 3 characters, only used by the monogram motif
+}
+
+// Motif draws the inner area of a card back inside the border
+type Motif func(b *strings.Builder, opts Options, x, y, w, h int)
+
+// Available motifs, keyed by the -motif flag value
+var motifs = map[string]Motif{
+	"lattice":  drawLattice,
+	"stripes":  drawStripes,
+	"dots":     drawDots,
+	"gradient": drawGradient,
+	"monogram": drawMonogram,
+}
+
+// Default set written by -all, so there is always a useful selection
+var presets = []Options{
+	{Motif: "lattice", Color: "#1f3a93", Accent: "#8fa8f0"},
+	{Motif: "stripes", Color: "#a31621", Accent: "#e86a73"},
+	{Motif: "dots", Color: "#1b5e20", Accent: "#81c784"},
+	{Motif: "gradient", Color: "#4a148c", Accent: "#ce93d8"},
+	{Motif: "monogram", Color: "#0d2538", Accent: "#d4af37", Monogram: "FC"},
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Helper function to check options before drawing
+func validate(opts Options) error {
+	if _, ok := motifs[opts.Motif]; !ok {
+		return fmt.Errorf("unknown motif %q (available: %s)", opts.Motif, strings.Join(motifNames(), ", "))
+	}
+	for _, c := range []string{opts.Color, opts.Accent} {
+		if !hexColor.MatchString(c) {
+			return fmt.Errorf("invalid color %q: use #rgb or #rrggbb", c)
+		}
+	}
+	if len([]rune(opts.Monogram)) > 3 {
+		return fmt.Errorf("monogram %q is too long: use at most 3 characters", opts.Monogram)
+	}
+	return nil
+}
+
+// Helper function to list motif names for usage and error messages
+func motifNames() []string {
+	names := make([]string, 0, len(motifs))
+	for name := range motifs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// renderBack returns a complete SVG document for a card back
+func renderBack(opts Options) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<?xml version="1.0" encoding="UTF-8"?>`+"\n")
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		cardWidth, cardHeight, cardWidth, cardHeight)
+
+	// Clip everything to the rounded card outline
+	fmt.Fprintf(&b, `<defs><clipPath id="card"><rect width="%d" height="%d" rx="%d"/></clipPath></defs>`+"\n",
+		cardWidth, cardHeight, cornerR)
+	fmt.Fprintf(&b, `<g clip-path="url(#card)">`+"\n")
+
+	// White border around the patterned area, as on traditional backs
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", cardWidth, cardHeight)
+	x, y := borderW, borderW
+	w, h := cardWidth-2*borderW, cardHeight-2*borderW
+	fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="%d" fill="%s"/>`+"\n",
+		x, y, w, h, cornerR/2, opts.Color)
+
+	motifs[opts.Motif](&b, opts, x, y, w, h)
+
+	fmt.Fprintf(&b, "</g>\n")
+	fmt.Fprintf(&b, `<rect x="0.5" y="0.5" width="%d" height="%d" rx="%d" fill="none" stroke="#999999"/>`+"\n",
+		cardWidth-1, cardHeight-1, cornerR)
+	fmt.Fprintf(&b, "</svg>\n")
+	return b.String()
+}
+
+// Diagonal diamond lattice, the classic casino back
+func drawLattice(b *strings.Builder, opts Options, x, y, w, h int) {
+	fmt.Fprintf(b, `<defs><pattern id="motif" width="20" height="20" patternUnits="userSpaceOnUse" patternTransform="rotate(45)">`+
+		`<path d="M0 0H20M0 0V20" stroke="%s" stroke-width="2" fill="none"/></pattern></defs>`+"\n", opts.Accent)
+	fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" fill="url(#motif)"/>`+"\n", x, y, w, h)
+	drawInnerFrame(b, opts, x, y, w, h)
+}
+
+// Evenly spaced diagonal stripes
+func drawStripes(b *strings.Builder, opts Options, x, y, w, h int) {
+	fmt.Fprintf(b, `<defs><pattern id="motif" width="16" height="16" patternUnits="userSpaceOnUse" patternTransform="rotate(-30)">`+
+		`<rect width="6" height="16" fill="%s"/></pattern></defs>`+"\n", opts.Accent)
+	fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" fill="url(#motif)"/>`+"\n", x, y, w, h)
+	drawInnerFrame(b, opts, x, y, w, h)
+}
+
+// Staggered polka dots
+func drawDots(b *strings.Builder, opts Options, x, y, w, h int) {
+	fmt.Fprintf(b, `<defs><pattern id="motif" width="24" height="24" patternUnits="userSpaceOnUse">`+
+		`<circle cx="6" cy="6" r="4" fill="%[1]s"/><circle cx="18" cy="18" r="4" fill="%[1]s"/></pattern></defs>`+"\n", opts.Accent)
+	fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" fill="url(#motif)"/>`+"\n", x, y, w, h)
+	drawInnerFrame(b, opts, x, y, w, h)
+}
+
+// Diagonal gradient from the main color to the accent
+func drawGradient(b *strings.Builder, opts Options, x, y, w, h int) {
+	fmt.Fprintf(b, `<defs><linearGradient id="motif" x1="0" y1="0" x2="1" y2="1">`+
+		`<stop offset="0" stop-color="%s"/><stop offset="1" stop-color="%s"/></linearGradient></defs>`+"\n",
+		opts.Color, opts.Accent)
+	fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" rx="%d" fill="url(#motif)"/>`+"\n", x, y, w, h, cornerR/2)
+	drawInnerFrame(b, opts, x, y, w, h)
+}
+
+// Plain back with centered initials in an oval cartouche
+func drawMonogram(b *strings.Builder, opts Options, x, y, w, h int) {
+	cx, cy := x+w/2, y+h/2
+	drawInnerFrame(b, opts, x, y, w, h)
+	fmt.Fprintf(b, `<ellipse cx="%d" cy="%d" rx="%d" ry="%d" fill="none" stroke="%s" stroke-width="3"/>`+"\n",
+		cx, cy, w/3, h/4, opts.Accent)
+
+	var text strings.Builder
+	xml.EscapeText(&text, []byte(opts.Monogram))
+	fmt.Fprintf(b, `<text x="%d" y="%d" font-family="Georgia, serif" font-size="%d" font-weight="bold" `+
+		`text-anchor="middle" dominant-baseline="central" fill="%s">%s</text>`+"\n",
+		cx, cy, w/5, opts.Accent, text.String())
+}
+
+// Thin inset frame shared by all motifs
+func drawInnerFrame(b *strings.Builder, opts Options, x, y, w, h int) {
+	const inset = 8
+	fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" rx="%d" fill="none" stroke="%s" stroke-width="2"/>`+"\n",
+		x+inset, y+inset, w-2*inset, h-2*inset, cornerR/2, opts.Accent)
+}
+
+// Helper function to build the default filename for a back
+func backFilename(opts Options) string {
+	return fmt.Sprintf("back_%s_%s.svg", opts.Motif, strings.TrimPrefix(strings.ToLower(opts.Color), "#"))
+}
+
+func main() {
+	motif := flag.String("motif", "lattice", "pattern to draw ("+strings.Join(motifNames(), ", ")+")")
+	color := flag.String("color", "#1f3a93", "main color (#rgb or #rrggbb)")
+	accent := flag.String("accent", "#ffffff", "pattern/detail color (#rgb or #rrggbb)")
+	monogram := flag.String("monogram", "FC", "initials for the monogram motif (up to 3 characters)")
+	name := flag.String("name", "", "output filename (default back_<motif>_<color>.svg)")
+	outDir := flag.String("out", "../../frontend/public/cards/backs", "directory to write SVG files to")
+	all := flag.Bool("all", false, "write the built-in preset selection instead of a single back")
+	flag.Parse()
+
+	var backs []Options
+	if *all {
+		backs = presets
+	} else {
+		backs = []Options{{Motif: *motif, Color: *color, Accent: *accent, Monogram: *monogram}}
+	}
+
+	if err := os.MkdirAll(*outDir, os.ModePerm); err != nil {
+		fmt.Println("Error creating directory:", err)
+		os.Exit(1)
+	}
+
+	for _, opts := range backs {
+		if err := validate(opts); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+
+		filename := backFilename(opts)
+		if *name != "" && !*all {
+			filename = *name
+		}
+		outPath := filepath.Join(*outDir, filename)
+
+		if err := os.WriteFile(outPath, []byte(renderBack(opts)), 0o644); err != nil {
+			fmt.Println("Error writing file:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✓ Wrote %s\n", outPath)
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/joshuamkite/freecell/precache_manifest
+
+go 1.25.5
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/joshuamkite/freecell/precache_manifest/precache"
+)
+
+func main() {
+	dir := flag.String("dir", "../../frontend/dist", "built frontend directory to scan")
+	out := flag.String("out", "", "manifest path (default <dir>/precache-manifest.json, or .js with -format=js)")
+	format := flag.String("format", "json", "output format: json, or js for importScripts()")
+	base := flag.String("base", "/", "URL prefix for every entry")
+	exclude := flag.String("exclude", strings.Join(precache.DefaultExclude, ","), "comma-separated glob patterns to leave out")
+	flag.Parse()
+
+	if *format != "json" && *format != "js" {
+		fmt.Printf("Error: unknown format %q (use json or js)\n", *format)
+		os.Exit(1)
+	}
+	if !strings.HasSuffix(*base, "/") {
+		*base += "/"
+	}
+
+	var patterns []string
+	for _, p := range strings.Split(*exclude, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+
+	m, err := precache.Build(*dir, *base, patterns)
+	if err != nil {
+		fmt.Println("Error scanning directory:", err)
+		os.Exit(1)
+	}
+
+	data, err := precache.Encode(m, *format)
+	if err != nil {
+		fmt.Println("Error encoding manifest:", err)
+		os.Exit(1)
+	}
+
+	outPath := *out
+	if outPath == "" {
+		outPath = filepath.Join(*dir, "precache-manifest."+*format)
+	}
+	if err := os.WriteFile(outPath, data, 0o644); err != nil {
+		fmt.Println("Error writing manifest:", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✓ Wrote %s (%d files, version %s)\n", outPath, len(m.Files), m.Version)
+}
@@ -0,0 +1,163 @@
+// Package precache builds the precache manifest read by the frontend's
+// service worker. It is shared by the precache_manifest and freecell_bundle
+// tools so both write exactly the same manifest for the same files.
+package precache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// AssetManifestName is the original-to-hashed mapping written by freecell_bundle
+const AssetManifestName = "asset-manifest.json"
+
+// DefaultExclude lists files the service worker must never precache:
+// source maps, the manifest itself and the service worker
+var DefaultExclude = []string{"*.map", "precache-manifest.*", "sw.js", "service-worker.js"}
+
+// Entry is one precached file, in the {url, revision} shape Workbox expects
+type Entry struct {
+	URL      string `json:"url"`
+	Revision string `json:"revision"`
+}
+
+// Manifest lists every file the service worker should precache.
+// Version changes whenever any file changes, so it can be used as the cache name.
+// Aliases maps the original URL of each hashed file to the hashed copy that is
+// cached in its place, since the game still requests some files by their original name.
+type Manifest struct {
+	Version string            `json:"version"`
+	Files   []Entry           `json:"files"`
+	Aliases map[string]string `json:"aliases,omitempty"`
+}
+
+// Helper function to hash a file's contents
+func hashFile(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Helper function to decide whether a file belongs in the precache.
+// Patterns are matched against both the base name and the relative path.
+func include(rel string, exclude []string) bool {
+	base := path.Base(rel)
+	if strings.HasPrefix(base, ".") {
+		return false
+	}
+	for _, pattern := range exclude {
+		if ok, _ := path.Match(pattern, base); ok {
+			return false
+		}
+		if ok, _ := path.Match(pattern, rel); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Helper function to read dir's asset manifest, if it has one
+func readAssetManifest(dir string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, AssetManifestName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var mapping map[string]string
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", AssetManifestName, err)
+	}
+	return mapping, nil
+}
+
+// Build walks dir and hashes every included file. If dir is a bundle with an
+// asset manifest, files that have a hashed copy are left out and listed as
+// aliases instead, so each file is only downloaded once.
+// Output is sorted and has no timestamps, so identical builds give identical manifests.
+func Build(dir, base string, exclude []string) (*Manifest, error) {
+	mapping, err := readAssetManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{Files: []Entry{}}
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if !include(rel, exclude) {
+			return nil
+		}
+		if hashed, ok := mapping[rel]; ok {
+			if m.Aliases == nil {
+				m.Aliases = map[string]string{}
+			}
+			m.Aliases[base+rel] = base + hashed
+			return nil
+		}
+
+		sum, err := hashFile(p)
+		if err != nil {
+			return err
+		}
+		m.Files = append(m.Files, Entry{URL: base + rel, Revision: sum[:16]})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].URL < m.Files[j].URL })
+
+	h := sha256.New()
+	for _, e := range m.Files {
+		fmt.Fprintf(h, "%s %s\n", e.URL, e.Revision)
+	}
+	m.Version = hex.EncodeToString(h.Sum(nil))[:16]
+	return m, nil
+}
+
+// Encode renders the manifest as JSON, or with format "js" as a script
+// that sets self.__PRECACHE_MANIFEST for the service worker's importScripts
+func Encode(m *Manifest, format string) ([]byte, error) {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case "json":
+	case "js":
+		data = append([]byte("self.__PRECACHE_MANIFEST = "), data...)
+		data = append(data, ';')
+	default:
+		return nil, fmt.Errorf("unknown format %q (use json or js)", format)
+	}
+	return append(data, '\n'), nil
+}
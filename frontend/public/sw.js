@@ -0,0 +1,74 @@
+// Service worker that precaches the files listed in precache-manifest.js
+// (written by dev_tooling/precache_manifest with -format=js) so the game works offline.
+//
+// The manifest is loaded with importScripts rather than fetch: browsers check
+// imported scripts for changes as well as this file, so a deploy that changes
+// any file changes the manifest and installs a new version of this worker.
+
+const CACHE_PREFIX = 'freecell-';
+
+try {
+  importScripts('/precache-manifest.js');
+} catch {
+  // No manifest in this build (e.g. `bun run precache` wasn't run): cache nothing
+}
+
+const manifest = self.__PRECACHE_MANIFEST || null;
+const cacheName = manifest ? CACHE_PREFIX + manifest.version : null;
+
+// Original URLs of hashed files, served from their hashed copy
+const aliases = (manifest && manifest.aliases) || {};
+
+self.addEventListener('install', (event) => {
+  event.waitUntil(
+    (async () => {
+      if (manifest) {
+        const cache = await caches.open(cacheName);
+        await cache.addAll(manifest.files.map((file) => file.url));
+      }
+      await self.skipWaiting();
+    })()
+  );
+});
+
+self.addEventListener('activate', (event) => {
+  event.waitUntil(
+    (async () => {
+      // Drop caches left behind by earlier deploys
+      const names = await caches.keys();
+      await Promise.all(
+        names
+          .filter((name) => name.startsWith(CACHE_PREFIX) && name !== cacheName)
+          .map((name) => caches.delete(name))
+      );
+      await self.clients.claim();
+    })()
+  );
+});
+
+self.addEventListener('fetch', (event) => {
+  const { request } = event;
+  if (request.method !== 'GET' || !cacheName) return;
+
+  event.respondWith(
+    (async () => {
+      const cache = await caches.open(cacheName);
+      const url = new URL(request.url);
+      const cached = url.origin === self.location.origin
+        ? await cache.match(aliases[url.pathname] || request)
+        : undefined;
+      if (cached) return cached;
+
+      try {
+        return await fetch(request);
+      } catch (error) {
+        // Offline: serve the app shell for page loads
+        if (request.mode === 'navigate') {
+          const shell = await cache.match('/index.html');
+          if (shell) return shell;
+        }
+        throw error;
+      }
+    })()
+  );
+});
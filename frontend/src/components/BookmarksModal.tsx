@@ -0,0 +1,173 @@
+import { useState } from 'react';
+import type { DealBookmark } from '../types/bookmark';
+import { SUGGESTED_BOOKMARK_TAGS, MAX_BOOKMARK_NOTE_LENGTH } from '../constants';
+import './BookmarksModal.css';
+
+interface BookmarksModalProps {
+    currentGameNumber: number;
+    bookmarks: DealBookmark[];
+    tags: string[];
+    getBookmark: (gameNumber: number) => DealBookmark | undefined;
+    saveBookmark: (gameNumber: number, note: string, tags: string[]) => void;
+    removeBookmark: (gameNumber: number) => void;
+    onPlay: (gameNumber: number) => void;
+    onClose: () => void;
+}
+
+export const BookmarksModal = ({
+    currentGameNumber,
+    bookmarks,
+    tags,
+    getBookmark,
+    saveBookmark,
+    removeBookmark,
+    onPlay,
+    onClose,
+}: BookmarksModalProps) => {
+    const current = getBookmark(currentGameNumber);
+    const [note, setNote] = useState(current?.note ?? '');
+    const [selectedTags, setSelectedTags] = useState<string[]>(current?.tags ?? []);
+    const [newTag, setNewTag] = useState('');
+    const [filterTag, setFilterTag] = useState<string | null>(null);
+
+    // Suggested tags first, then the player's own
+    const tagChoices = [...new Set([...SUGGESTED_BOOKMARK_TAGS, ...tags, ...selectedTags])];
+    const shownBookmarks = filterTag ? bookmarks.filter(b => b.tags.includes(filterTag)) : bookmarks;
+
+    const toggleTag = (tag: string) => {
+        setSelectedTags(selected =>
+            selected.includes(tag) ? selected.filter(t => t !== tag) : [...selected, tag]
+        );
+    };
+
+    const addNewTag = () => {
+        const tag = newTag.trim().toLowerCase();
+        if (tag && !selectedTags.includes(tag)) {
+            setSelectedTags([...selectedTags, tag]);
+        }
+        setNewTag('');
+    };
+
+    const handleRemove = (gameNumber: number) => {
+        removeBookmark(gameNumber);
+        if (gameNumber === currentGameNumber) {
+            setNote('');
+            setSelectedTags([]);
+        }
+    };
+
+    return (
+        <div className="bookmarks-container">
+            <div className="bookmarks-header">
+                <h1>Bookmarks</h1>
+                <button className="close-bookmarks-button" onClick={onClose}>
+                    ✕ Close
+                </button>
+            </div>
+
+            <div className="bookmarks-content">
+                <section className="bookmarks-section">
+                    <h2>Game #{currentGameNumber}</h2>
+                    <label className="bookmark-note">
+                        Note
+                        <textarea
+                            value={note}
+                            maxLength={MAX_BOOKMARK_NOTE_LENGTH}
+                            rows={3}
+                            placeholder="What makes this deal worth coming back to?"
+                            onChange={(e) => setNote(e.target.value)}
+                        />
+                    </label>
+
+                    <div className="bookmark-tags">
+                        {tagChoices.map(tag => (
+                            <button
+                                key={tag}
+                                className={`bookmark-tag ${selectedTags.includes(tag) ? 'selected' : ''}`}
+                                aria-pressed={selectedTags.includes(tag)}
+                                onClick={() => toggleTag(tag)}
+                            >
+                                {tag}
+                            </button>
+                        ))}
+                        <input
+                            type="text"
+                            value={newTag}
+                            placeholder="New tag"
+                            aria-label="New tag"
+                            onChange={(e) => setNewTag(e.target.value)}
+                            onKeyDown={(e) => {
+                                if (e.key === 'Enter') addNewTag();
+                            }}
+                        />
+                        <button className="bookmark-tag" onClick={addNewTag} disabled={!newTag.trim()}>
+                            + Add
+                        </button>
+                    </div>
+
+                    <div className="bookmark-actions">
+                        <button onClick={() => saveBookmark(currentGameNumber, note, selectedTags)}>
+                            {current ? 'Update Bookmark' : 'Bookmark This Deal'}
+                        </button>
+                        {current && (
+                            <button onClick={() => handleRemove(currentGameNumber)}>
+                                Remove Bookmark
+                            </button>
+                        )}
+                    </div>
+                </section>
+
+                <section className="bookmarks-section">
+                    <h2>Saved Deals</h2>
+                    {tags.length > 0 && (
+                        <div className="bookmark-tags">
+                            <button
+                                className={`bookmark-tag ${filterTag === null ? 'selected' : ''}`}
+                                aria-pressed={filterTag === null}
+                                onClick={() => setFilterTag(null)}
+                            >
+                                all
+                            </button>
+                            {tags.map(tag => (
+                                <button
+                                    key={tag}
+                                    className={`bookmark-tag ${filterTag === tag ? 'selected' : ''}`}
+                                    aria-pressed={filterTag === tag}
+                                    onClick={() => setFilterTag(tag)}
+                                >
+                                    {tag}
+                                </button>
+                            ))}
+                        </div>
+                    )}
+
+                    {shownBookmarks.length === 0 ? (
+                        <p>No bookmarked deals{filterTag ? ` tagged "${filterTag}"` : ' yet'}.</p>
+                    ) : (
+                        <ul className="bookmark-list">
+                            {shownBookmarks.map(bookmark => (
+                                <li key={bookmark.gameNumber}>
+                                    <div className="bookmark-summary">
+                                        <strong>Game #{bookmark.gameNumber}</strong>
+                                        {bookmark.tags.map(tag => (
+                                            <span key={tag} className="bookmark-tag-label">{tag}</span>
+                                        ))}
+                                        {bookmark.note && <p>{bookmark.note}</p>}
+                                    </div>
+                                    <div className="bookmark-actions">
+                                        <button onClick={() => onPlay(bookmark.gameNumber)}>
+                                            Play
+                                        </button>
+                                        <button onClick={() => handleRemove(bookmark.gameNumber)}>
+                                            Remove
+                                        </button>
+                                    </div>
+                                </li>
+                            ))}
+                        </ul>
+                    )}
+                </section>
+            </div>
+        </div>
+    );
+};
@@ -32,7 +32,7 @@ export function Card({
     return (
         <div
             ref={cardRef}
-            className={`card ${className}`}
+            className={`card ${card.colorClass} ${className}`}
             onClick={onClick}
             onDoubleClick={onDoubleClick}
             draggable={draggable}
@@ -44,9 +44,11 @@ export function Card({
         >
             <img
                 src={imagePath}
-                alt={`${card.rank} of ${card.suit}`}
+                alt={card.label}
                 draggable={false}
             />
+            {/* Colorblind-safe marker: red and black differ in shape, not just color */}
+            <span className="card-suit-marker" aria-hidden="true">{card.symbol}</span>
         </div>
     );
 }
@@ -1,9 +1,11 @@
 import { useState, useEffect, useRef } from 'react';
 import type { Card as CardType } from '../types/card';
+import type { AutoPlayMode } from '../types/settings';
 import { Card } from './Card';
 import { AnimatedCard } from './AnimatedCard';
 import { VictoryAnimation } from './VictoryAnimation';
 import { LicenseModal } from './LicenseModal';
+import { BookmarksModal } from './BookmarksModal';
 import { canMoveToFoundation } from '../game/freecellLogic';
 import { checkWin } from '../game/freecellLogic';
 import {
@@ -13,6 +15,8 @@ import {
     useAutoPlay,
     useDragAndDrop,
     useGameNumber,
+    useAutoPlaySettings,
+    useBookmarks,
 } from '../hooks';
 import {
     MAX_GAME_NUMBER,
@@ -21,6 +25,8 @@ import {
     LAST_ITEM_INDEX_OFFSET,
     DRAG_OVERLAY_Z_INDEX,
     DRAG_OVERLAY_TRANSFORM,
+    MIN_AUTO_MOVE_SAFE_RANK_OFFSET,
+    MAX_AUTO_MOVE_SAFE_RANK_OFFSET,
 } from '../constants';
 import './GameBoard.css';
 
@@ -33,6 +39,7 @@ export function GameBoard() {
     const [selectedCard, setSelectedCard] = useState<{ card: CardType; location: { type: string; index: number } } | null>(null);
     const [showVictory, setShowVictory] = useState(false);
     const [showLicense, setShowLicense] = useState(false);
+    const [showBookmarks, setShowBookmarks] = useState(false);
     const [showHelp, setShowHelp] = useState(false);
     const [showMoreHelp, setShowMoreHelp] = useState(false);
     const gameBoardRef = useRef<HTMLDivElement>(null);
@@ -80,12 +87,19 @@ export function GameBoard() {
         tableauColumnRefs
     );
 
+    // Auto-play setting hook (saved between visits)
+    const { settings: autoPlaySettings, setMode: setAutoPlayMode, setSafeRankOffset } = useAutoPlaySettings();
+
+    // Bookmarked deals hook (saved between visits)
+    const { bookmarks, tags: bookmarkTags, getBookmark, saveBookmark, removeBookmark } = useBookmarks();
+
     // Auto-play hook
     const { triggerAutoPlay } = useAutoPlay(
         gameStateRef,
         animateMove,
         detectAutoPlayMove,
-        dispatch
+        dispatch,
+        autoPlaySettings
     );
 
     // Store triggerAutoPlay in ref for use in animation callback
@@ -266,6 +280,36 @@ export function GameBoard() {
                     <button onClick={handleButtonClick}>
                         {getButtonLabel()}
                     </button>
+                    <button onClick={() => setShowBookmarks(true)} title="Bookmark this deal, or play a saved one">
+                        {getBookmark(currentGameNumber) ? '★' : '☆'} Bookmarks
+                    </button>
+                    <label>
+                        Auto-play:
+                        <select
+                            value={autoPlaySettings.mode}
+                            onChange={(e) => setAutoPlayMode(e.target.value as AutoPlayMode)}
+                        >
+                            <option value="off">Off</option>
+                            <option value="safe">Safe cards</option>
+                            <option value="full">Safe cards + finish</option>
+                        </select>
+                    </label>
+                    {autoPlaySettings.mode !== 'off' && (
+                        <label title="How far a card may be above the lowest opposite-color foundation and still be auto-played">
+                            Margin:
+                            <select
+                                value={autoPlaySettings.safeRankOffset}
+                                onChange={(e) => setSafeRankOffset(parseInt(e.target.value))}
+                            >
+                                {Array.from(
+                                    { length: MAX_AUTO_MOVE_SAFE_RANK_OFFSET - MIN_AUTO_MOVE_SAFE_RANK_OFFSET + 1 },
+                                    (_, i) => MIN_AUTO_MOVE_SAFE_RANK_OFFSET + i
+                                ).map(offset => (
+                                    <option key={offset} value={offset}>{offset}</option>
+                                ))}
+                            </select>
+                        </label>
+                    )}
                 </div>
             </div>
 
@@ -432,6 +476,22 @@ export function GameBoard() {
                 <LicenseModal onClose={() => setShowLicense(false)} />
             )}
 
+            {showBookmarks && (
+                <BookmarksModal
+                    currentGameNumber={currentGameNumber}
+                    bookmarks={bookmarks}
+                    tags={bookmarkTags}
+                    getBookmark={getBookmark}
+                    saveBookmark={saveBookmark}
+                    removeBookmark={removeBookmark}
+                    onPlay={(gameNumber) => {
+                        setShowBookmarks(false);
+                        startNewGame(gameNumber);
+                    }}
+                    onClose={() => setShowBookmarks(false)}
+                />
+            )}
+
             {/* How to Play Modal */}
             {showHelp && (
                 <div className="help-overlay">
@@ -475,7 +535,9 @@ export function GameBoard() {
                                         <h3>Foundation Rules</h3>
                                         <ul>
                                             <li>Build up by suit from Ace to King</li>
-                                            <li>Cards are automatically moved when safe</li>
+                                            <li>Cards are automatically moved when safe (change this with the Auto-play setting)</li>
+                                            <li>Auto-play margin: 1 only moves cards that can never be needed again; higher values move cards sooner</li>
+                                            <li>"Safe cards + finish" also plays out the rest of the game once every column runs downwards</li>
                                         </ul>
 
                                         <h3>Tips</h3>
@@ -185,6 +185,37 @@ export const VICTORY_ANIMATION_DELAY_MS = 500;
  */
 export const AUTO_MOVE_SAFE_RANK_OFFSET = 2;
 
+/**
+ * Range of safe rank offsets a player can choose in the auto-play setting
+ * 1 only plays cards that can never be needed again; higher values play more eagerly
+ */
+export const MIN_AUTO_MOVE_SAFE_RANK_OFFSET = 1;
+export const MAX_AUTO_MOVE_SAFE_RANK_OFFSET = 4;
+
+/**
+ * localStorage key for the player's auto-play setting
+ */
+export const AUTO_PLAY_SETTINGS_STORAGE_KEY = 'freecell.autoPlaySettings';
+
+// ============================================================================
+// BOOKMARKS
+// ============================================================================
+
+/**
+ * localStorage key for the player's bookmarked deals
+ */
+export const BOOKMARKS_STORAGE_KEY = 'freecell.bookmarks';
+
+/**
+ * Tags offered when bookmarking a deal; players can add their own too
+ */
+export const SUGGESTED_BOOKMARK_TAGS = ['hard', 'fun', 'teach supermoves'];
+
+/**
+ * Longest note kept with a bookmark, in characters
+ */
+export const MAX_BOOKMARK_NOTE_LENGTH = 500;
+
 // ============================================================================
 // ARRAY & INDEX OPERATIONS
 // ============================================================================
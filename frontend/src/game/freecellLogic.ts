@@ -1,6 +1,6 @@
 import type { Card, Suit, Rank } from '../types/card';
 import type { GameState } from '../types/gameState';
-import { createCardId, getCardColor, getRankValue } from '../types/card';
+import { createCard, getCardColor, getRankValue } from '../types/card';
 import { shuffleFreeCellDeck } from '../utils/freecellRng';
 
 /**
@@ -17,11 +17,7 @@ export function createDeck(): Card[] {
     // Microsoft FreeCell order: ranks first, then suits (SHDC)
     for (const rank of ranks) {
         for (const suit of suits) {
-            deck.push({
-                suit,
-                rank,
-                id: createCardId(suit, rank),
-            });
+            deck.push(createCard(suit, rank));
         }
     }
 
@@ -0,0 +1,92 @@
+import type { Card, Suit } from '../types/card';
+import type { GameState } from '../types/gameState';
+import { createCardId, getRankValue } from '../types/card';
+import { createDeck } from './freecellLogic';
+
+const SUITS: Suit[] = ['hearts', 'diamonds', 'clubs', 'spades'];
+
+/**
+ * List the invariants a game state breaks, or an empty list if it is consistent:
+ * - 8 tableau columns and 4 free cells
+ * - every one of the 52 cards appears exactly once
+ * - each foundation holds only its own suit, from the ace up with no gaps
+ * - each card's id matches its suit and rank
+ */
+export function findInvariantViolations(state: GameState): string[] {
+    const violations: string[] = [];
+    const seen = new Map<string, string>();
+
+    const place = (card: Card, where: string) => {
+        if (card.id !== createCardId(card.suit, card.rank)) {
+            violations.push(`${card.id} in ${where} is the ${card.rank} of ${card.suit}`);
+        }
+        const previous = seen.get(card.id);
+        if (previous) {
+            violations.push(`${card.id} is in both ${previous} and ${where}`);
+        } else {
+            seen.set(card.id, where);
+        }
+    };
+
+    if (state.tableau.length !== 8) {
+        violations.push(`${state.tableau.length} tableau columns, want 8`);
+    }
+    if (state.freeCells.length !== 4) {
+        violations.push(`${state.freeCells.length} free cells, want 4`);
+    }
+
+    for (const suit of SUITS) {
+        state.foundations[suit].forEach((card, index) => {
+            if (card.suit !== suit || getRankValue(card.rank) !== index + 1) {
+                violations.push(`${suit} foundation has ${card.id} in position ${index + 1}`);
+            }
+            place(card, `${suit} foundation`);
+        });
+    }
+    state.freeCells.forEach((card, index) => {
+        if (card) place(card, `free cell ${index + 1}`);
+    });
+    state.tableau.forEach((column, index) => {
+        column.forEach(card => place(card, `column ${index + 1}`));
+    });
+
+    const missing = createDeck().filter(card => !seen.has(card.id));
+    if (missing.length > 0) {
+        violations.push(`missing ${missing.map(card => card.id).join(', ')}`);
+    }
+    if (seen.size !== 52) {
+        violations.push(`${seen.size} distinct cards on the board, want 52`);
+    }
+
+    return violations;
+}
+
+/**
+ * Describe a game state one pile per line, for invariant violation reports
+ */
+export function describeGameState(state: GameState): string {
+    const ids = (cards: (Card | null)[]) => cards.map(card => card?.id ?? '--').join(' ');
+    return [
+        `Game #${state.gameNumber}`,
+        `Free cells: ${ids(state.freeCells)}`,
+        ...SUITS.map(suit => `Foundation ${suit}: ${ids(state.foundations[suit])}`),
+        ...state.tableau.map((column, index) => `Column ${index + 1}: ${ids(column)}`),
+    ].join('\n');
+}
+
+/**
+ * Throw with a description of both states if next breaks an invariant
+ *
+ * Run after every state change in development builds, so a bug in the move
+ * logic stops the game at the move that caused it.
+ */
+export function assertInvariants(previous: GameState, next: GameState): void {
+    const violations = findInvariantViolations(next);
+    if (violations.length === 0) return;
+
+    throw new Error(
+        `FreeCell invariant violated:\n${violations.join('\n')}\n\n` +
+        `Before:\n${describeGameState(previous)}\n\n` +
+        `After:\n${describeGameState(next)}`
+    );
+}
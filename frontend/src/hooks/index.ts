@@ -11,3 +11,5 @@ export { useCardAnimation } from './useCardAnimation';
 export { useAutoPlay } from './useAutoPlay';
 export { useDragAndDrop } from './useDragAndDrop';
 export { useGameNumber } from './useGameNumber';
+export { useAutoPlaySettings } from './useAutoPlaySettings';
+export { useBookmarks } from './useBookmarks';
@@ -1,11 +1,11 @@
 import type { RefObject, Dispatch } from 'react';
 import type { GameState } from '../types/gameState';
 import type { Card as CardType } from '../types/card';
+import type { AutoPlaySettings } from '../types/settings';
 import { getRankValue } from '../types/card';
 import { canMoveToFoundation, checkWin } from '../game/freecellLogic';
 import {
     MIN_GAME_NUMBER,
-    AUTO_MOVE_SAFE_RANK_OFFSET,
     AUTO_PLAY_DELAY_MS,
     LAST_ITEM_INDEX_OFFSET,
 } from '../constants';
@@ -22,14 +22,17 @@ type GameAction =
  * Custom hook for auto-play functionality
  * 
  * Automatically moves safe cards to foundations after each manual move.
- * A card is considered "safe" if its rank is at most 2 higher than the minimum
- * rank of opposite color foundations, preventing situations where you might
- * need the card later.
+ * A card is considered "safe" if its rank is at most the player's safe rank
+ * offset (2 by default) higher than the minimum rank of opposite color
+ * foundations, preventing situations where you might need the card later.
+ * In "full" mode every card is moved once no column can block the finish,
+ * and in "off" mode nothing is moved.
  * 
  * @param gameStateRef - Ref to current game state for immediate access
  * @param animateMove - Function to animate a card movement
  * @param detectAutoPlayMove - Function to detect which card moved between states
  * @param dispatch - Dispatch function for game state updates
+ * @param settings - The player's auto-play setting
  * @returns Object containing the triggerAutoPlay function
  */
 export function useAutoPlay(
@@ -48,7 +51,8 @@ export function useAutoPlay(
         toPile: 'foundation';
         toIndex: number;
     } | null,
-    dispatch: Dispatch<GameAction>
+    dispatch: Dispatch<GameAction>,
+    settings: AutoPlaySettings
 ) {
     /**
      * Try to auto-play a single card (returns new state if moved, or same state if not)
@@ -83,15 +87,26 @@ export function useAutoPlay(
         };
 
         /**
-         * A card is safe to auto-move if its rank is at most 2 higher than
-         * the minimum rank of opposite color foundations
+         * Helper: The game can be finished without any help once every column
+         * runs downwards in rank, since the lowest remaining card is then always on top
+         */
+        const canAutoFinish = (): boolean => {
+            return newState.tableau.every(column =>
+                column.every((card, i) => i === 0 || getRankValue(card.rank) <= getRankValue(column[i - 1].rank))
+            );
+        };
+        const finishing = settings.mode === 'full' && canAutoFinish();
+
+        /**
+         * A card is safe to auto-move if its rank is at most the safe rank
+         * offset higher than the minimum rank of opposite color foundations
          */
         const isSafeToAutoMove = (card: CardType): boolean => {
             const cardRankValue = getRankValue(card.rank);
             const currentFoundationRank = newState.foundations[card.suit].length;
             const minOppositeRank = getMinOppositeColorRank(card.suit);
             return cardRankValue === currentFoundationRank + MIN_GAME_NUMBER &&
-                cardRankValue <= minOppositeRank + AUTO_MOVE_SAFE_RANK_OFFSET;
+                (finishing || cardRankValue <= minOppositeRank + settings.safeRankOffset);
         };
 
         // Try to move from free cells first
@@ -127,7 +142,7 @@ export function useAutoPlay(
      */
     const triggerAutoPlay = (newState: GameState) => {
         // Auto-play: automatically move safe cards to foundations one at a time
-        if (!checkWin(newState)) {
+        if (settings.mode !== 'off' && !checkWin(newState)) {
             // Recursive function to move cards one at a time with delays
             const autoPlayRecursive = () => {
                 setTimeout(() => {
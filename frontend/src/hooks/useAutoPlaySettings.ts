@@ -0,0 +1,76 @@
+import { useState, useEffect } from 'react';
+import type { AutoPlayMode, AutoPlaySettings } from '../types/settings';
+import {
+    AUTO_MOVE_SAFE_RANK_OFFSET,
+    MIN_AUTO_MOVE_SAFE_RANK_OFFSET,
+    MAX_AUTO_MOVE_SAFE_RANK_OFFSET,
+    AUTO_PLAY_SETTINGS_STORAGE_KEY,
+} from '../constants';
+
+const DEFAULT_SETTINGS: AutoPlaySettings = {
+    mode: 'safe',
+    safeRankOffset: AUTO_MOVE_SAFE_RANK_OFFSET,
+};
+
+/**
+ * Keep a safe rank offset within the range the player can choose
+ */
+function clampSafeRankOffset(offset: number): number {
+    return Math.max(MIN_AUTO_MOVE_SAFE_RANK_OFFSET, Math.min(MAX_AUTO_MOVE_SAFE_RANK_OFFSET, Math.round(offset)));
+}
+
+/**
+ * Read saved settings, falling back to the defaults for anything missing or invalid
+ */
+function loadSettings(): AutoPlaySettings {
+    try {
+        const saved = window.localStorage.getItem(AUTO_PLAY_SETTINGS_STORAGE_KEY);
+        if (!saved) return DEFAULT_SETTINGS;
+
+        const parsed = JSON.parse(saved) as Partial<AutoPlaySettings>;
+        const modes: AutoPlayMode[] = ['off', 'safe', 'full'];
+        return {
+            mode: parsed.mode && modes.includes(parsed.mode) ? parsed.mode : DEFAULT_SETTINGS.mode,
+            safeRankOffset: typeof parsed.safeRankOffset === 'number'
+                ? clampSafeRankOffset(parsed.safeRankOffset)
+                : DEFAULT_SETTINGS.safeRankOffset,
+        };
+    } catch {
+        // Storage unavailable or corrupted
+        return DEFAULT_SETTINGS;
+    }
+}
+
+/**
+ * Custom hook for the player's auto-play setting
+ *
+ * The setting is saved in localStorage so it carries over between games and visits.
+ *
+ * @returns Object containing the current settings and setters for each field
+ */
+export function useAutoPlaySettings() {
+    const [settings, setSettings] = useState<AutoPlaySettings>(loadSettings);
+
+    // Save whenever the settings change
+    useEffect(() => {
+        try {
+            window.localStorage.setItem(AUTO_PLAY_SETTINGS_STORAGE_KEY, JSON.stringify(settings));
+        } catch {
+            // Storage unavailable (e.g. private browsing); keep the setting for this visit only
+        }
+    }, [settings]);
+
+    const setMode = (mode: AutoPlayMode) => {
+        setSettings(current => ({ ...current, mode }));
+    };
+
+    const setSafeRankOffset = (offset: number) => {
+        setSettings(current => ({ ...current, safeRankOffset: clampSafeRankOffset(offset) }));
+    };
+
+    return {
+        settings,
+        setMode,
+        setSafeRankOffset,
+    };
+}
@@ -0,0 +1,123 @@
+import { useState, useEffect } from 'react';
+import type { DealBookmark } from '../types/bookmark';
+import {
+    MIN_GAME_NUMBER,
+    MAX_GAME_NUMBER,
+    BOOKMARKS_STORAGE_KEY,
+    MAX_BOOKMARK_NOTE_LENGTH,
+} from '../constants';
+
+/**
+ * Tidy tags for storage: trimmed, lowercase, no blanks or duplicates
+ */
+function normalizeTags(tags: string[]): string[] {
+    const cleaned = tags.map(tag => tag.trim().toLowerCase()).filter(tag => tag.length > 0);
+    return [...new Set(cleaned)];
+}
+
+/**
+ * Check whether a deal number is one the game can start
+ */
+function isValidGameNumber(gameNumber: unknown): gameNumber is number {
+    return typeof gameNumber === 'number'
+        && Number.isInteger(gameNumber)
+        && gameNumber >= MIN_GAME_NUMBER
+        && gameNumber <= MAX_GAME_NUMBER;
+}
+
+/**
+ * Read saved bookmarks, skipping any entry that is missing or invalid
+ */
+function loadBookmarks(): DealBookmark[] {
+    try {
+        const saved = window.localStorage.getItem(BOOKMARKS_STORAGE_KEY);
+        if (!saved) return [];
+
+        const parsed: unknown = JSON.parse(saved);
+        if (!Array.isArray(parsed)) return [];
+
+        const bookmarks: DealBookmark[] = [];
+        for (const entry of parsed as Partial<DealBookmark>[]) {
+            const gameNumber = entry?.gameNumber;
+            if (!isValidGameNumber(gameNumber)) continue;
+            if (bookmarks.some(b => b.gameNumber === gameNumber)) continue;
+            bookmarks.push({
+                gameNumber,
+                note: typeof entry.note === 'string' ? entry.note.slice(0, MAX_BOOKMARK_NOTE_LENGTH) : '',
+                tags: Array.isArray(entry.tags)
+                    ? normalizeTags(entry.tags.filter((tag): tag is string => typeof tag === 'string'))
+                    : [],
+                createdAt: typeof entry.createdAt === 'number' ? entry.createdAt : Date.now(),
+            });
+        }
+        return bookmarks;
+    } catch {
+        // Storage unavailable or corrupted
+        return [];
+    }
+}
+
+/**
+ * Custom hook for the player's bookmarked deals
+ *
+ * Each deal has at most one bookmark, with a note and tags. Bookmarks are saved
+ * in localStorage so they carry over between visits.
+ *
+ * @returns Object containing the bookmarks (newest first), every tag in use, and functions to look up, save and remove bookmarks
+ */
+export function useBookmarks() {
+    const [bookmarks, setBookmarks] = useState<DealBookmark[]>(loadBookmarks);
+
+    // Save whenever the bookmarks change
+    useEffect(() => {
+        try {
+            window.localStorage.setItem(BOOKMARKS_STORAGE_KEY, JSON.stringify(bookmarks));
+        } catch {
+            // Storage unavailable (e.g. private browsing); keep bookmarks for this visit only
+        }
+    }, [bookmarks]);
+
+    /**
+     * Get the bookmark for a deal, if it has one
+     */
+    const getBookmark = (gameNumber: number): DealBookmark | undefined => {
+        return bookmarks.find(b => b.gameNumber === gameNumber);
+    };
+
+    /**
+     * Bookmark a deal, or update its note and tags if it is already bookmarked
+     */
+    const saveBookmark = (gameNumber: number, note: string, tags: string[]) => {
+        if (!isValidGameNumber(gameNumber)) return;
+        setBookmarks(current => {
+            const existing = current.find(b => b.gameNumber === gameNumber);
+            const bookmark: DealBookmark = {
+                gameNumber,
+                note: note.trim().slice(0, MAX_BOOKMARK_NOTE_LENGTH),
+                tags: normalizeTags(tags),
+                createdAt: existing?.createdAt ?? Date.now(),
+            };
+            return existing
+                ? current.map(b => (b.gameNumber === gameNumber ? bookmark : b))
+                : [...current, bookmark];
+        });
+    };
+
+    /**
+     * Remove a deal's bookmark
+     */
+    const removeBookmark = (gameNumber: number) => {
+        setBookmarks(current => current.filter(b => b.gameNumber !== gameNumber));
+    };
+
+    const sortedBookmarks = [...bookmarks].sort((a, b) => b.createdAt - a.createdAt);
+    const tags = [...new Set(bookmarks.flatMap(b => b.tags))].sort();
+
+    return {
+        bookmarks: sortedBookmarks,
+        tags,
+        getBookmark,
+        saveBookmark,
+        removeBookmark,
+    };
+}
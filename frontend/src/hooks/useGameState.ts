@@ -1,6 +1,7 @@
 import { useReducer, useRef, useEffect } from 'react';
 import type { GameState } from '../types/gameState';
 import { dealCards } from '../game/freecellLogic';
+import { assertInvariants } from '../game/invariants';
 import { LAST_ITEM_INDEX_OFFSET } from '../constants';
 
 /**
@@ -25,6 +26,10 @@ interface GameReducerState {
 function gameReducer(state: GameReducerState, action: GameAction): GameReducerState {
     switch (action.type) {
         case 'UPDATE_STATE':
+            // Development builds check every move, to catch move logic bugs where they happen
+            if (import.meta.env.DEV) {
+                assertInvariants(state.current, action.newState);
+            }
             return {
                 current: action.newState,
                 history: [...state.history, state.current],
@@ -8,3 +8,12 @@ createRoot(document.getElementById('root')!).render(
     <App />
   </StrictMode>,
 )
+
+// Register the offline service worker in production builds only, so the
+// dev server never serves stale cached files
+if (import.meta.env.PROD && 'serviceWorker' in navigator) {
+  window.addEventListener('load', () => {
+    // Skip the HTTP cache when checking for updates, so a new manifest is seen straight away
+    navigator.serviceWorker.register('/sw.js', { updateViaCache: 'none' })
+  })
+}
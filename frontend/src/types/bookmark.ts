@@ -0,0 +1,14 @@
+// A deal the player saved to come back to, e.g. to replay or to show someone
+export interface DealBookmark {
+    // Deal number, as entered in the Game # box
+    gameNumber: number;
+
+    // Free-text note, e.g. "needs an early supermove"
+    note: string;
+
+    // Lowercase tags such as "hard", "fun" or "teach supermoves"
+    tags: string[];
+
+    // When the bookmark was first saved (ms since the epoch)
+    createdAt: number;
+}
@@ -1,14 +1,18 @@
 export type Suit = 'hearts' | 'diamonds' | 'clubs' | 'spades';
 export type Rank = 'ace' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9' | '10' | 'jack' | 'queen' | 'king';
 
+export type CardColor = 'red' | 'black';
+export type CardColorClass = `card-${CardColor}`;
+
 export interface Card {
     suit: Suit;
     rank: Rank;
     id: string; // Unique identifier for the card
+    symbol: string; // Suit symbol, e.g. "♥"
+    colorClass: CardColorClass; // CSS class for the card's color (see Card.css)
+    label: string; // Accessible name, e.g. "ten of hearts"
 }
 
-export type CardColor = 'red' | 'black';
-
 // Helper to get card color
 export function getCardColor(suit: Suit): CardColor {
     return suit === 'hearts' || suit === 'diamonds' ? 'red' : 'black';
@@ -34,11 +38,59 @@ export function getRankValue(rank: Rank): number {
     return rankValues[rank];
 }
 
+// Helper to get suit symbol
+export function getSuitSymbol(suit: Suit): string {
+    const suitSymbols: Record<Suit, string> = {
+        'hearts': '♥',
+        'diamonds': '♦',
+        'clubs': '♣',
+        'spades': '♠',
+    };
+    return suitSymbols[suit];
+}
+
+// Helper to get the CSS class for a card's color
+export function getCardColorClass(suit: Suit): CardColorClass {
+    return `card-${getCardColor(suit)}`;
+}
+
+// Helper to get a card's accessible name, e.g. "ten of hearts"
+export function getCardLabel(suit: Suit, rank: Rank): string {
+    const rankWords: Record<Rank, string> = {
+        'ace': 'ace',
+        '2': 'two',
+        '3': 'three',
+        '4': 'four',
+        '5': 'five',
+        '6': 'six',
+        '7': 'seven',
+        '8': 'eight',
+        '9': 'nine',
+        '10': 'ten',
+        'jack': 'jack',
+        'queen': 'queen',
+        'king': 'king',
+    };
+    return `${rankWords[rank]} of ${suit}`;
+}
+
 // Helper to create card ID
 export function createCardId(suit: Suit, rank: Rank): string {
     return `${rank}_of_${suit}`;
 }
 
+// Helper to create a card with its metadata
+export function createCard(suit: Suit, rank: Rank): Card {
+    return {
+        suit,
+        rank,
+        id: createCardId(suit, rank),
+        symbol: getSuitSymbol(suit),
+        colorClass: getCardColorClass(suit),
+        label: getCardLabel(suit, rank),
+    };
+}
+
 // Helper to get card image filename
 export function getCardImagePath(card: Card): string {
     return `/cards/English_pattern_${card.rank}_of_${card.suit}.svg`;
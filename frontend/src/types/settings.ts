@@ -0,0 +1,13 @@
+// Auto-play levels:
+// - off: never move cards to the foundations automatically
+// - safe: only move cards that can't be needed in the tableau again
+// - full: as safe, and finish the game once nothing can block it
+export type AutoPlayMode = 'off' | 'safe' | 'full';
+
+export interface AutoPlaySettings {
+    mode: AutoPlayMode;
+
+    // A card is safe when its rank is at most this much higher than the
+    // lowest opposite-color foundation
+    safeRankOffset: number;
+}